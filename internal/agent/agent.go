@@ -1,13 +1,18 @@
 package agent
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"Orkflow/internal/logging"
 	"Orkflow/internal/memory"
+	"Orkflow/internal/metrics"
 	"Orkflow/internal/tools"
 	"Orkflow/pkg/types"
+
+	"github.com/hashicorp/go-hclog"
 )
 
 const maxRetries = 3
@@ -16,27 +21,86 @@ type Runner struct {
 	Config          *types.WorkflowConfig
 	Context         *ContextManager
 	Clients         map[string]LLMClient
+	modelProvider   map[string]string // model name -> provider, for metrics labels
 	SessionHistory  string
-	MessageCallback func(agentID, role, content string) // Called when agent completes
-	SharedMemory    *memory.SharedMemory                // Shared memory for inter-agent communication
-	Logger          *logging.Logger                     // Execution logger
+	MessageCallback func(agentID, role, content string)    // Called when agent completes
+	TokenCallback   func(agentID, token string)            // Called for each incremental token when streaming
+	ToolCallback    func(agentID, toolName, output string) // Called after each tool call resolves
+	SharedMemory    *memory.SharedMemory                   // Shared memory for inter-agent communication
+	Logger          *logging.Logger                        // File-based execution logger
+	Log             hclog.Logger                           // Structured logger for operational events
+	Metrics         *metrics.Metrics                       // Prometheus metrics, nil disables collection
+}
+
+// Option configures a Runner at construction time.
+type Option func(*Runner)
+
+// WithLogger sets the structured hclog.Logger used for operational
+// events (agent lifecycle, retries, shared-memory publish/wait). Defaults
+// to a colorized human-readable logger at Info level when not provided.
+func WithLogger(log hclog.Logger) Option {
+	return func(r *Runner) {
+		r.Log = log
+	}
+}
+
+// WithMetrics attaches a Prometheus metrics collector. When unset, metrics
+// are not recorded, so tests and one-off runs don't pay the overhead.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(r *Runner) {
+		r.Metrics = m
+	}
 }
 
-func NewRunner(config *types.WorkflowConfig) *Runner {
+func NewRunner(config *types.WorkflowConfig, opts ...Option) *Runner {
 	runner := &Runner{
-		Config:  config,
-		Context: NewContextManager(),
-		Clients: make(map[string]LLMClient),
+		Config:        config,
+		Context:       NewContextManager(),
+		Clients:       make(map[string]LLMClient),
+		modelProvider: make(map[string]string),
+		Log: hclog.New(&hclog.LoggerOptions{
+			Name:  "orkflow",
+			Level: hclog.Info,
+			Color: hclog.AutoColor,
+		}),
+	}
+
+	for _, opt := range opts {
+		opt(runner)
 	}
 
 	for name, model := range config.Models {
-		fmt.Printf("DEBUG: Creating client for model '%s' with provider='%s' model='%s'\n", name, model.Provider, model.Model)
+		runner.Log.Debug("creating LLM client", "model", name, "provider", model.Provider, "target_model", model.Model)
 		runner.Clients[name] = NewLLMClient(
 			model.Provider,
 			model.Model,
 			model.APIKey,
 			model.Endpoint,
 		)
+		runner.modelProvider[name] = model.Provider
+	}
+
+	// Wrap any model that declares fallbacks in a FallbackClient chaining its
+	// own client with its fallbacks' clients, in declared order.
+	for name, model := range config.Models {
+		if len(model.Fallbacks) == 0 {
+			continue
+		}
+
+		names := []string{name}
+		clients := []LLMClient{runner.Clients[name]}
+		for _, fallbackName := range model.Fallbacks {
+			fallbackClient, ok := runner.Clients[fallbackName]
+			if !ok {
+				runner.Log.Warn("skipping unknown fallback model", "model", name, "fallback", fallbackName)
+				continue
+			}
+			names = append(names, fallbackName)
+			clients = append(clients, fallbackClient)
+		}
+
+		runner.Log.Debug("composing fallback chain", "model", name, "chain", names)
+		runner.Clients[name] = NewFallbackClient(names, clients, runner.Log, runner.Metrics)
 	}
 
 	return runner
@@ -56,6 +120,23 @@ var spinnerStyles = [][]string{
 	{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}, // braille
 }
 
+// classifyFailureReason buckets a generate error into a short label suitable
+// for a metrics dimension, so dashboards don't explode into one series per
+// unique error message.
+func classifyFailureReason(err error) string {
+	msg := strings.ToUpper(err.Error())
+	switch {
+	case strings.Contains(msg, "QUOTA_EXCEEDED") || strings.Contains(msg, "RATE LIMIT"):
+		return "rate_limited"
+	case strings.Contains(msg, "INVALID API KEY") || strings.Contains(msg, "UNAUTHORIZED"):
+		return "auth"
+	case strings.Contains(msg, "TIMEOUT") || strings.Contains(msg, "DEADLINE"):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
 func getSpinnerForAgent(agentID string) []string {
 	hash := 0
 	for _, c := range agentID {
@@ -64,7 +145,10 @@ func getSpinnerForAgent(agentID string) []string {
 	return spinnerStyles[hash%len(spinnerStyles)]
 }
 
-func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
+// RunAgent runs a single agent to completion. ctx governs the underlying
+// LLMClient.Generate calls - a step timeout or an aborted run cancels an
+// in-flight generation rather than only refusing to start the next retry.
+func (r *Runner) RunAgent(ctx context.Context, agentDef *types.Agent) (string, error) {
 	client, ok := r.Clients[agentDef.Model]
 	if !ok {
 		return "", fmt.Errorf("model not found: %s", agentDef.Model)
@@ -72,7 +156,7 @@ func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
 
 	// Wait for required keys from shared memory
 	if r.SharedMemory != nil && len(agentDef.Requires) > 0 {
-		fmt.Printf("[%s] ⏳ Waiting for required data: %v\n", agentDef.ID, agentDef.Requires)
+		r.Log.Info("waiting for required shared-memory keys", "agent_id", agentDef.ID, "keys", agentDef.Requires)
 		for _, key := range agentDef.Requires {
 			val, err := r.SharedMemory.WaitFor(key, 5*time.Minute) // 5 min timeout for slow models
 			if err != nil {
@@ -80,13 +164,13 @@ func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
 			}
 			// Inject into context
 			r.Context.AddOutput(fmt.Sprintf("shared:%s", key), fmt.Sprintf("%v", val))
-			fmt.Printf("[%s] ✓ Received '%s' from shared memory\n", agentDef.ID, key)
+			r.Log.Info("received shared-memory key", "agent_id", agentDef.ID, "key", key)
 		}
 	}
 
 	prompt := r.buildPrompt(agentDef)
 	spinner := getSpinnerForAgent(agentDef.ID)
-	fmt.Printf("[%s] Running agent: %s\n", agentDef.ID, agentDef.Role)
+	r.Log.Info("agent started", "agent_id", agentDef.ID, "role", agentDef.Role)
 	if r.Logger != nil {
 		r.Logger.LogAgent(agentDef.ID, "STARTED", fmt.Sprintf("Role: %s", agentDef.Role))
 	}
@@ -95,66 +179,134 @@ func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
 	var err error
 	startTime := time.Now()
 
-	// Start progress indicator (log-based for parallel compatibility)
+	streamingClient, canStream := client.(StreamingClient)
+	useStreaming := canStream && r.TokenCallback != nil
+
 	done := make(chan bool)
-	go func() {
-		i := 0
-		lastLog := time.Now()
-		for {
-			select {
-			case <-done:
-				return
-			default:
-				elapsed := time.Since(startTime).Seconds()
-				// Log every 5 seconds for parallel agents
-				if time.Since(lastLog) >= 5*time.Second {
-					fmt.Printf("[%s] %s Still generating... (%.0fs)\n", agentDef.ID, spinner[i%len(spinner)], elapsed)
-					lastLog = time.Now()
+	if !useStreaming {
+		// Start progress indicator (log-based for parallel compatibility)
+		go func() {
+			i := 0
+			lastLog := time.Now()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					elapsed := time.Since(startTime).Seconds()
+					// Log every 5 seconds for parallel agents
+					if time.Since(lastLog) >= 5*time.Second {
+						r.Log.Debug("still generating", "agent_id", agentDef.ID, "spinner", spinner[i%len(spinner)], "elapsed_s", elapsed)
+						lastLog = time.Now()
+					}
+					i++
+					time.Sleep(100 * time.Millisecond)
 				}
-				i++
-				time.Sleep(100 * time.Millisecond)
 			}
+		}()
+	}
+
+	provider := r.modelProvider[agentDef.Model]
+	agentTools := r.resolveTools(agentDef)
+	toolCaller, canCallTools := client.(ToolCallingClient)
+	useToolCalling := canCallTools && !useStreaming && len(agentTools) > 0
+
+	var toolSpecs []tools.ToolSpec
+	var grammar string
+	if useToolCalling {
+		toolSpecs = tools.BuildToolSpecs(agentTools)
+		if g, gerr := tools.ToGBNF(agentTools); gerr == nil {
+			grammar = g
+		} else {
+			r.Log.Debug("skipping grammar constraint", "agent_id", agentDef.ID, "error", gerr)
 		}
-	}()
+	}
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		response, err = client.Generate(prompt)
+		genStart := time.Now()
+		switch {
+		case useStreaming:
+			response, err = streamingClient.GenerateStream(prompt, func(tok Token) {
+				if r.TokenCallback != nil {
+					r.TokenCallback(agentDef.ID, tok.Content)
+				}
+			})
+		case useToolCalling:
+			response, err = toolCaller.GenerateWithTools(prompt, toolSpecs, grammar)
+		default:
+			response, err = client.Generate(ctx, prompt)
+		}
+		if r.Metrics != nil {
+			r.Metrics.LLMGenerateDuration.WithLabelValues(provider, agentDef.Model).Observe(time.Since(genStart).Seconds())
+		}
 		if err == nil {
 			break
 		}
-		fmt.Printf("[%s] Attempt %d failed: %v\n", agentDef.ID, attempt, err)
+		r.Log.Warn("agent generate attempt failed", "agent_id", agentDef.ID, "attempt", attempt, "error", err)
+		if r.Metrics != nil {
+			r.Metrics.LLMRetriesTotal.WithLabelValues(provider, classifyFailureReason(err)).Inc()
+		}
 
 		if attempt < maxRetries {
-			fmt.Printf("[%s] Retrying in %d seconds...\n", agentDef.ID, attempt)
+			r.Log.Info("retrying agent generate", "agent_id", agentDef.ID, "attempt", attempt, "backoff_s", attempt)
 			time.Sleep(time.Second * time.Duration(attempt))
 		}
 	}
 
-	close(done)
+	if !useStreaming {
+		close(done)
+	}
 	elapsed := time.Since(startTime)
 
 	if err != nil {
+		if r.Metrics != nil {
+			r.Metrics.AgentRunsTotal.WithLabelValues(agentDef.ID, agentDef.Model, "failed").Inc()
+			r.Metrics.AgentDuration.WithLabelValues(agentDef.ID, agentDef.Model).Observe(elapsed.Seconds())
+		}
 		return "", fmt.Errorf("agent %s failed after %d attempts: %w", agentDef.ID, maxRetries, err)
 	}
 
-	fmt.Printf("[%s] ✓ Completed in %.1fs (%d chars)\n", agentDef.ID, elapsed.Seconds(), len(response))
+	if r.Metrics != nil {
+		r.Metrics.AgentRunsTotal.WithLabelValues(agentDef.ID, agentDef.Model, "success").Inc()
+		r.Metrics.AgentDuration.WithLabelValues(agentDef.ID, agentDef.Model).Observe(elapsed.Seconds())
+	}
 
-	// Handle tool calls if agent has tools
-	if len(agentDef.Tools) > 0 && tools.HasToolCalls(response) {
-		toolCalls := tools.ParseToolCalls(response)
-		if len(toolCalls) > 0 {
-			results := tools.ExecuteToolCalls(toolCalls)
+	r.Log.Info("agent completed", "agent_id", agentDef.ID, "duration_ms", elapsed.Milliseconds(), "response_chars", len(response))
 
-			// Log tool execution
-			if r.Logger != nil {
-				for i, res := range results {
-					input := toolCalls[i].Input
-					output := res.Output
-					if res.Error != nil {
-						output = fmt.Sprintf("ERROR: %v", res.Error)
-					}
+	// Handle tool calls if agent has tools. The response is parsed as a
+	// structured {"name","arguments"} call first; if it is not one, it falls
+	// back to the ```tool:name``` regex fence format.
+	if len(agentDef.Tools) > 0 {
+		var toolCalls []tools.ToolCall
+		if structured, serr := tools.ParseStructuredToolCall(response); serr == nil {
+			call, cerr := structured.ToToolCall()
+			if cerr != nil {
+				r.Log.Warn("structured tool call failed validation", "agent_id", agentDef.ID, "error", cerr)
+			} else {
+				toolCalls = []tools.ToolCall{call}
+			}
+		} else if tools.HasToolCalls(response) {
+			toolCalls = tools.ParseToolCalls(response)
+		}
+
+		if len(toolCalls) > 0 {
+			results := tools.ExecuteToolCalls(toolCalls, r.Metrics, r.Log)
+
+			for i, res := range results {
+				input := toolCalls[i].Input
+				output := res.Output
+				if res.Error != nil {
+					output = fmt.Sprintf("ERROR: %v", res.Error)
+					r.Log.Warn("tool call failed", "agent_id", agentDef.ID, "tool", res.ToolName, "error", res.Error)
+				} else {
+					r.Log.Info("tool call completed", "agent_id", agentDef.ID, "tool", res.ToolName)
+				}
+				if r.Logger != nil {
 					r.Logger.LogToolCall(res.ToolName, input, output)
 				}
+				if r.ToolCallback != nil {
+					r.ToolCallback(agentDef.ID, res.ToolName, output)
+				}
 			}
 
 			toolOutput := tools.FormatToolResults(results)
@@ -162,10 +314,10 @@ func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
 			// Make a follow-up call with tool results
 			if toolOutput != "" {
 				followupPrompt := prompt + "\n\nPrevious response:\n" + response + toolOutput + "\n\nNow provide your final response incorporating the tool results:"
-				followupResponse, followupErr := client.Generate(followupPrompt)
+				followupResponse, followupErr := client.Generate(ctx, followupPrompt)
 				if followupErr == nil {
 					response = followupResponse
-					fmt.Printf("[%s] ✓ Follow-up completed (%d chars)\n", agentDef.ID, len(response))
+					r.Log.Info("tool follow-up completed", "agent_id", agentDef.ID, "response_chars", len(response))
 				}
 			}
 		}
@@ -177,7 +329,7 @@ func (r *Runner) RunAgent(agentDef *types.Agent) (string, error) {
 	if r.SharedMemory != nil && len(agentDef.Outputs) > 0 {
 		for _, key := range agentDef.Outputs {
 			r.SharedMemory.Set(key, response)
-			fmt.Printf("[%s] 📤 Published '%s' to shared memory\n", agentDef.ID, key)
+			r.Log.Info("published to shared memory", "agent_id", agentDef.ID, "key", key)
 			if r.Logger != nil {
 				r.Logger.LogAgent(agentDef.ID, "SHARED_MEMORY_PUBLISH", key)
 			}
@@ -212,10 +364,24 @@ func (r *Runner) buildPrompt(agentDef *types.Agent) string {
 		prompt = prompt + "\n\n" + context
 	}
 
-	// Add tool descriptions if agent has tools or toolsets
+	// Add tool descriptions if agent has tools or toolsets. A
+	// ToolCallingClient is still given the text description as a hint of
+	// what each tool does, on top of the structured schema it receives
+	// separately - see resolveTools and RunAgent's useToolCalling branch.
+	allTools := r.resolveTools(agentDef)
+	if len(allTools) > 0 {
+		prompt = prompt + "\n\n" + tools.FormatToolsForPrompt(allTools)
+	}
+
+	return prompt
+}
+
+// resolveTools collects every tool available to agentDef: its explicitly
+// listed tools plus every tool registered under a "serverName." prefix for
+// each of its toolsets (MCP servers).
+func (r *Runner) resolveTools(agentDef *types.Agent) []tools.Tool {
 	var allTools []tools.Tool
 
-	// 1. Add explicitly listed tools
 	if len(agentDef.Tools) > 0 {
 		agentTools, err := tools.GetByNames(agentDef.Tools)
 		if err == nil {
@@ -223,20 +389,14 @@ func (r *Runner) buildPrompt(agentDef *types.Agent) string {
 		}
 	}
 
-	// 2. Add tools from toolsets (MCP servers)
 	if len(agentDef.Toolsets) > 0 {
 		for _, toolset := range agentDef.Toolsets {
-			// Get tools starting with "serverName."
 			setTools := tools.GetByPrefix(toolset + ".")
 			allTools = append(allTools, setTools...)
 		}
 	}
 
-	if len(allTools) > 0 {
-		prompt = prompt + "\n\n" + tools.FormatToolsForPrompt(allTools)
-	}
-
-	return prompt
+	return allTools
 }
 
 func (r *Runner) GetAgent(id string) *types.Agent {
@@ -0,0 +1,120 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	backendpb "Orkflow/internal/agent/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// BackendSpec declares an out-of-process gRPC backend to launch before a
+// workflow runs, read from ~/.orka.yaml's `backends:` list, e.g.:
+//
+//	backends:
+//	  - name: my-model
+//	    cmd: ./llama-backend
+//	    args: ["--port", "50051"]
+//	    addr: "unix:///tmp/my-model.sock"
+//
+// A workflow references it the same way it references any other model,
+// with provider: grpc and endpoint matching Addr.
+type BackendSpec struct {
+	Name string   `yaml:"name"`
+	Cmd  string   `yaml:"cmd"`
+	Args []string `yaml:"args,omitempty"`
+	Addr string   `yaml:"addr"`
+}
+
+// BackendProcess is a running backend spawned from a BackendSpec. Stop
+// terminates the subprocess; callers should defer it once the workflow
+// using the backend has finished.
+type BackendProcess struct {
+	Spec BackendSpec
+	cmd  *exec.Cmd
+}
+
+// Stop terminates the subprocess. Safe to call even if the process has
+// already exited.
+func (p *BackendProcess) Stop() error {
+	if p.cmd == nil || p.cmd.Process == nil {
+		return nil
+	}
+	return p.cmd.Process.Kill()
+}
+
+// healthCheckInterval and healthCheckRetries bound how long SpawnBackends
+// waits for a freshly launched backend to report ready before giving up.
+const (
+	healthCheckInterval = 500 * time.Millisecond
+	healthCheckRetries  = 20
+)
+
+// SpawnBackends launches every spec's Cmd as a subprocess and waits for its
+// Health RPC to report ready, retrying on healthCheckInterval up to
+// healthCheckRetries times. If any backend fails to become healthy, every
+// already-spawned process (including the failing one) is stopped and an
+// error is returned, so a workflow never starts against a half-up fleet of
+// backends.
+func SpawnBackends(specs []BackendSpec) ([]*BackendProcess, error) {
+	var procs []*BackendProcess
+
+	for _, spec := range specs {
+		cmd := exec.Command(spec.Cmd, spec.Args...)
+		if err := cmd.Start(); err != nil {
+			stopAll(procs)
+			return nil, fmt.Errorf("spawning backend %q: %w", spec.Name, err)
+		}
+		proc := &BackendProcess{Spec: spec, cmd: cmd}
+		procs = append(procs, proc)
+
+		if err := waitHealthy(spec); err != nil {
+			stopAll(procs)
+			return nil, fmt.Errorf("backend %q never became healthy: %w", spec.Name, err)
+		}
+	}
+
+	return procs, nil
+}
+
+// waitHealthy polls spec.Addr's Health RPC until it reports ready or the
+// retry budget is exhausted.
+func waitHealthy(spec BackendSpec) error {
+	target := strings.TrimPrefix(spec.Addr, "tcp://")
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("dialing %q: %w", spec.Addr, err)
+	}
+	client := backendpb.NewBackendClient(conn)
+
+	var lastErr error
+	for attempt := 0; attempt < healthCheckRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckInterval)
+		resp, err := client.Health(ctx, &backendpb.HealthRequest{})
+		cancel()
+
+		if err == nil && resp.Ready {
+			return nil
+		}
+		lastErr = err
+		if err == nil && !resp.Ready {
+			lastErr = fmt.Errorf("not ready: %s", resp.Message)
+		}
+		time.Sleep(healthCheckInterval)
+	}
+
+	return fmt.Errorf("after %d attempts: %w", healthCheckRetries, lastErr)
+}
+
+// stopAll kills every already-spawned process, logging nothing - callers
+// are already mid-failure and will report the real error themselves.
+func stopAll(procs []*BackendProcess) {
+	for _, p := range procs {
+		p.Stop()
+	}
+}
@@ -2,10 +2,13 @@ package agent
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+
+	"Orkflow/internal/tools"
 )
 
 type ClaudeClient struct {
@@ -13,7 +16,7 @@ type ClaudeClient struct {
 	Model  string
 }
 
-func (c *ClaudeClient) Generate(prompt string) (string, error) {
+func (c *ClaudeClient) Generate(ctx context.Context, prompt string) (string, error) {
 	payload := map[string]interface{}{
 		"model":      c.Model,
 		"max_tokens": 4096,
@@ -23,7 +26,7 @@ func (c *ClaudeClient) Generate(prompt string) (string, error) {
 	}
 
 	body, _ := json.Marshal(payload)
-	req, err := http.NewRequest("POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
@@ -58,3 +61,71 @@ func (c *ClaudeClient) Generate(prompt string) (string, error) {
 
 	return result.Content[0].Text, nil
 }
+
+// GenerateWithTools implements ToolCallingClient using Claude's native
+// `tools`/input_schema request fields. grammar is ignored - Claude has no
+// grammar constraint, only structured tool use.
+func (c *ClaudeClient) GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error) {
+	payload := map[string]interface{}{
+		"model":      c.Model,
+		"max_tokens": 4096,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if len(toolSpecs) > 0 {
+		claudeTools := make([]map[string]interface{}, 0, len(toolSpecs))
+		for _, spec := range toolSpecs {
+			claudeTools = append(claudeTools, map[string]interface{}{
+				"name":         spec.Function.Name,
+				"description":  spec.Function.Description,
+				"input_schema": spec.Function.Parameters,
+			})
+		}
+		payload["tools"] = claudeTools
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("x-api-key", c.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("claude api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Content []struct {
+			Type  string          `json:"type"`
+			Text  string          `json:"text"`
+			Name  string          `json:"name"`
+			Input json.RawMessage `json:"input"`
+		} `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	if len(result.Content) == 0 {
+		return "", fmt.Errorf("no response from claude")
+	}
+
+	for _, block := range result.Content {
+		if block.Type == "tool_use" {
+			return encodeStructuredCall(block.Name, block.Input), nil
+		}
+	}
+	return result.Content[0].Text, nil
+}
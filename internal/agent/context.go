@@ -55,3 +55,13 @@ func (cm *ContextManager) GetLastOutput() string {
 func (cm *ContextManager) Clear() {
 	cm.History = []AgentOutput{}
 }
+
+// OutputsByAgent returns each agent's most recent response, keyed by agent
+// ID, for checkpointing or inspection.
+func (cm *ContextManager) OutputsByAgent() map[string]string {
+	outputs := make(map[string]string, len(cm.History))
+	for _, o := range cm.History {
+		outputs[o.AgentID] = o.Response
+	}
+	return outputs
+}
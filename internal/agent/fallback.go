@@ -0,0 +1,207 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"Orkflow/internal/metrics"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// breakerState mirrors the classic closed/open/half-open circuit breaker
+// states: closed lets traffic through, open rejects it outright, half-open
+// lets a single probe through after the cooldown to decide whether to close
+// again or re-open.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	breakerFailureWindow    = 1 * time.Minute
+	breakerFailureThreshold = 3
+	breakerCooldown         = 30 * time.Second
+)
+
+// CircuitBreaker tracks recent failures for a single provider and trips open
+// once breakerFailureThreshold failures land inside breakerFailureWindow, so
+// a flapping provider is skipped for breakerCooldown instead of being re-hit
+// on every agent.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	provider string
+	state    breakerState
+	failures []time.Time
+	openedAt time.Time
+	log      hclog.Logger
+	metrics  *metrics.Metrics
+}
+
+// NewCircuitBreaker creates a closed breaker for the given provider name.
+func NewCircuitBreaker(provider string, log hclog.Logger, m *metrics.Metrics) *CircuitBreaker {
+	return &CircuitBreaker{
+		provider: provider,
+		log:      log,
+		metrics:  m,
+	}
+}
+
+// Allow reports whether a call should be attempted right now, flipping an
+// open breaker to half-open once the cooldown has elapsed.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen {
+		if time.Since(b.openedAt) >= breakerCooldown {
+			b.transition(breakerHalfOpen)
+			return true
+		}
+		return false
+	}
+
+	// The single half-open probe is already in flight; hold further calls
+	// until it records a success or failure.
+	if b.state == breakerHalfOpen {
+		return false
+	}
+
+	return true
+}
+
+// RecordSuccess closes the breaker, clearing any failure history.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = nil
+	if b.state != breakerClosed {
+		b.transition(breakerClosed)
+	}
+}
+
+// RecordFailure appends to the sliding failure window and trips the breaker
+// open once the threshold is reached within the window. A failure while
+// half-open re-opens immediately.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.transition(breakerOpen)
+		return
+	}
+
+	now := time.Now()
+	b.failures = append(b.failures, now)
+
+	cutoff := now.Add(-breakerFailureWindow)
+	kept := b.failures[:0]
+	for _, t := range b.failures {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	b.failures = kept
+
+	if len(b.failures) >= breakerFailureThreshold {
+		b.transition(breakerOpen)
+	}
+}
+
+// transition must be called with b.mu held.
+func (b *CircuitBreaker) transition(to breakerState) {
+	from := b.state
+	b.state = to
+	if to == breakerOpen {
+		b.openedAt = time.Now()
+	}
+
+	if b.log != nil {
+		b.log.Info("circuit breaker state transition", "provider", b.provider, "from", from.String(), "to", to.String())
+	}
+	if b.metrics != nil {
+		b.metrics.BreakerTransitionsTotal.WithLabelValues(b.provider, to.String()).Inc()
+	}
+}
+
+// fallbackEntry pairs a wrapped client with the breaker guarding it.
+type fallbackEntry struct {
+	name    string
+	client  LLMClient
+	breaker *CircuitBreaker
+}
+
+// FallbackClient wraps an ordered list of LLMClients (e.g. primary Groq ->
+// secondary OpenRouter -> local Ollama) and transparently retries against the
+// next client when one fails, skipping any client whose breaker is open.
+type FallbackClient struct {
+	entries []fallbackEntry
+	log     hclog.Logger
+}
+
+// NewFallbackClient builds a FallbackClient over clients in priority order.
+// names must be parallel to clients and is used for breaker/log labels.
+func NewFallbackClient(names []string, clients []LLMClient, log hclog.Logger, m *metrics.Metrics) *FallbackClient {
+	entries := make([]fallbackEntry, len(clients))
+	for i, c := range clients {
+		entries[i] = fallbackEntry{
+			name:    names[i],
+			client:  c,
+			breaker: NewCircuitBreaker(names[i], log, m),
+		}
+	}
+	return &FallbackClient{entries: entries, log: log}
+}
+
+// Generate tries each client in order, skipping any whose breaker is open,
+// and returns the first success. If every client fails or is tripped open,
+// it returns the last error seen.
+func (f *FallbackClient) Generate(ctx context.Context, prompt string) (string, error) {
+	var lastErr error
+
+	for _, e := range f.entries {
+		if !e.breaker.Allow() {
+			if f.log != nil {
+				f.log.Debug("skipping provider, circuit breaker open", "provider", e.name)
+			}
+			continue
+		}
+
+		response, err := e.client.Generate(ctx, prompt)
+		if err == nil {
+			e.breaker.RecordSuccess()
+			return response, nil
+		}
+
+		e.breaker.RecordFailure()
+		if f.log != nil {
+			f.log.Warn("fallback provider failed", "provider", e.name, "error", err)
+		}
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return "", fmt.Errorf("all fallback providers unavailable: circuit breakers open")
+	}
+	return "", fmt.Errorf("all fallback providers failed: %w", lastErr)
+}
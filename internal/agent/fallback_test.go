@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeLLMClient returns resp/err on every Generate call and counts how many
+// times it was invoked.
+type fakeLLMClient struct {
+	resp  string
+	err   error
+	calls int
+}
+
+func (f *fakeLLMClient) Generate(ctx context.Context, prompt string) (string, error) {
+	f.calls++
+	return f.resp, f.err
+}
+
+func TestFallbackClientUsesNextOnFailure(t *testing.T) {
+	primary := &fakeLLMClient{err: errors.New("primary down")}
+	secondary := &fakeLLMClient{resp: "from secondary"}
+
+	fc := NewFallbackClient([]string{"primary", "secondary"}, []LLMClient{primary, secondary}, nil, nil)
+
+	resp, err := fc.Generate(context.Background(), "hi")
+	if err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	if resp != "from secondary" {
+		t.Errorf("Generate() = %q, want %q", resp, "from secondary")
+	}
+	if primary.calls != 1 || secondary.calls != 1 {
+		t.Errorf("calls = primary:%d secondary:%d, want 1,1", primary.calls, secondary.calls)
+	}
+}
+
+func TestFallbackClientAllFail(t *testing.T) {
+	primary := &fakeLLMClient{err: errors.New("primary down")}
+	secondary := &fakeLLMClient{err: errors.New("secondary down")}
+
+	fc := NewFallbackClient([]string{"primary", "secondary"}, []LLMClient{primary, secondary}, nil, nil)
+
+	if _, err := fc.Generate(context.Background(), "hi"); err == nil {
+		t.Fatal("Generate() error = nil, want an error when every provider fails")
+	}
+}
+
+func TestCircuitBreakerTripsOpenAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker("test-provider", nil, nil)
+
+	for i := 0; i < breakerFailureThreshold; i++ {
+		if !b.Allow() {
+			t.Fatalf("Allow() = false before threshold reached (failure %d)", i)
+		}
+		b.RecordFailure()
+	}
+
+	if b.Allow() {
+		t.Error("Allow() = true after breakerFailureThreshold failures, want false (breaker open)")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessCloses(t *testing.T) {
+	b := NewCircuitBreaker("test-provider", nil, nil)
+
+	for i := 0; i < breakerFailureThreshold-1; i++ {
+		b.RecordFailure()
+	}
+	b.RecordSuccess()
+
+	if !b.Allow() {
+		t.Error("Allow() = false after RecordSuccess, want true (breaker closed, failures cleared)")
+	}
+	if len(b.failures) != 0 {
+		t.Errorf("failures = %v after RecordSuccess, want empty", b.failures)
+	}
+}
+
+func TestFallbackClientSkipsOpenBreaker(t *testing.T) {
+	primary := &fakeLLMClient{err: errors.New("primary down")}
+	secondary := &fakeLLMClient{resp: "ok"}
+
+	fc := NewFallbackClient([]string{"primary", "secondary"}, []LLMClient{primary, secondary}, nil, nil)
+
+	// Trip primary's breaker open by failing past the threshold.
+	for i := 0; i < breakerFailureThreshold; i++ {
+		fc.entries[0].breaker.RecordFailure()
+	}
+
+	if _, err := fc.Generate(context.Background(), "hi"); err != nil {
+		t.Fatalf("Generate() error = %v, want nil", err)
+	}
+	if primary.calls != 0 {
+		t.Errorf("primary.calls = %d, want 0 (breaker should be skipped, not called)", primary.calls)
+	}
+}
@@ -2,10 +2,13 @@ package agent
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+
+	"Orkflow/internal/tools"
 )
 
 type GeminiClient struct {
@@ -13,7 +16,7 @@ type GeminiClient struct {
 	Model  string
 }
 
-func (g *GeminiClient) Generate(prompt string) (string, error) {
+func (g *GeminiClient) Generate(ctx context.Context, prompt string) (string, error) {
 	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", g.Model, g.APIKey)
 
 	payload := map[string]interface{}{
@@ -27,7 +30,7 @@ func (g *GeminiClient) Generate(prompt string) (string, error) {
 	}
 
 	body, _ := json.Marshal(payload)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
@@ -70,3 +73,85 @@ func (g *GeminiClient) Generate(prompt string) (string, error) {
 
 	return result.Candidates[0].Content.Parts[0].Text, nil
 }
+
+// GenerateWithTools implements ToolCallingClient using Gemini's native
+// functionDeclarations request field. grammar is ignored - Gemini has no
+// grammar constraint, only structured function calling.
+func (g *GeminiClient) GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error) {
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", g.Model, g.APIKey)
+
+	payload := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"parts": []map[string]string{
+					{"text": prompt},
+				},
+			},
+		},
+	}
+	if len(toolSpecs) > 0 {
+		decls := make([]map[string]interface{}, 0, len(toolSpecs))
+		for _, spec := range toolSpecs {
+			decls = append(decls, map[string]interface{}{
+				"name":        spec.Function.Name,
+				"description": spec.Function.Description,
+				"parameters":  spec.Function.Parameters,
+			})
+		}
+		payload["tools"] = []map[string]interface{}{
+			{"functionDeclarations": decls},
+		}
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return "", fmt.Errorf("QUOTA_EXCEEDED[%s]: quota limit reached", g.Model)
+		}
+
+		return "", fmt.Errorf("gemini api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text         string `json:"text"`
+					FunctionCall *struct {
+						Name string          `json:"name"`
+						Args json.RawMessage `json:"args"`
+					} `json:"functionCall"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
+		return "", fmt.Errorf("no response from gemini")
+	}
+
+	for _, part := range result.Candidates[0].Content.Parts {
+		if part.FunctionCall != nil {
+			return encodeStructuredCall(part.FunctionCall.Name, part.FunctionCall.Args), nil
+		}
+	}
+	return result.Candidates[0].Content.Parts[0].Text, nil
+}
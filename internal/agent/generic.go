@@ -1,12 +1,16 @@
 package agent
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+
+	"Orkflow/internal/tools"
 )
 
 // Known OpenAI-compatible API endpoints
@@ -49,7 +53,7 @@ func NewGenericClient(provider, model, apiKey, endpoint string) *GenericClient {
 	}
 }
 
-func (g *GenericClient) Generate(prompt string) (string, error) {
+func (g *GenericClient) Generate(ctx context.Context, prompt string) (string, error) {
 	payload := map[string]interface{}{
 		"model": g.Model,
 		"messages": []map[string]string{
@@ -58,7 +62,7 @@ func (g *GenericClient) Generate(prompt string) (string, error) {
 	}
 
 	body, _ := json.Marshal(payload)
-	req, err := http.NewRequest("POST", g.Endpoint, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", g.Endpoint, bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
@@ -111,3 +115,173 @@ func (g *GenericClient) Generate(prompt string) (string, error) {
 
 	return result.Choices[0].Message.Content, nil
 }
+
+// GenerateWithTools implements ToolCallingClient for OpenAI-compatible
+// local servers. It sends both toolSpecs (as OpenAI-style native tool
+// calling, for vLLM and similar) and grammar (as the llama.cpp server's
+// `grammar` extension field) when provided, since which one a given
+// endpoint honors depends on the backend behind g.Provider.
+func (g *GenericClient) GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error) {
+	payload := map[string]interface{}{
+		"model": g.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if len(toolSpecs) > 0 {
+		payload["tools"] = toolSpecs
+		payload["tool_choice"] = "auto"
+	}
+	if grammar != "" {
+		payload["grammar"] = grammar
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", g.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s connection error: %w", g.Provider, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		errStr := string(respBody)
+		if strings.Contains(errStr, "invalid_api_key") || strings.Contains(errStr, "Unauthorized") {
+			return "", fmt.Errorf("%s: invalid API key", g.Provider)
+		}
+		if strings.Contains(errStr, "rate_limit") || strings.Contains(errStr, "quota") {
+			return "", fmt.Errorf("QUOTA_EXCEEDED[%s]: rate limit reached", g.Provider)
+		}
+		return "", fmt.Errorf("%s API error (%d): %s", g.Provider, resp.StatusCode, errStr)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("%s: failed to parse response: %w", g.Provider, err)
+	}
+
+	if result.Error.Message != "" {
+		return "", fmt.Errorf("%s error: %s", g.Provider, result.Error.Message)
+	}
+
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no response from %s", g.Provider)
+	}
+
+	msg := result.Choices[0].Message
+	if len(msg.ToolCalls) > 0 {
+		fn := msg.ToolCalls[0].Function
+		return encodeStructuredCall(fn.Name, json.RawMessage(fn.Arguments)), nil
+	}
+	return msg.Content, nil
+}
+
+// GenerateStream implements StreamingClient by setting "stream": true and
+// parsing the SSE "data:" chunks that OpenAI-compatible endpoints return,
+// invoking onToken for each incremental delta as it arrives.
+func (g *GenericClient) GenerateStream(prompt string, onToken func(Token)) (string, error) {
+	payload := map[string]interface{}{
+		"model": g.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"stream": true,
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", g.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s connection error: %w", g.Provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		errStr := string(respBody)
+		if strings.Contains(errStr, "invalid_api_key") || strings.Contains(errStr, "Unauthorized") {
+			return "", fmt.Errorf("%s: invalid API key", g.Provider)
+		}
+		if strings.Contains(errStr, "rate_limit") || strings.Contains(errStr, "quota") {
+			return "", fmt.Errorf("QUOTA_EXCEEDED[%s]: rate limit reached", g.Provider)
+		}
+		return "", fmt.Errorf("%s API error (%d): %s", g.Provider, resp.StatusCode, errStr)
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+				FinishReason string `json:"finish_reason"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue // ignore malformed/keep-alive frames
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			full.WriteString(choice.Delta.Content)
+			if onToken != nil {
+				onToken(Token{Content: choice.Delta.Content, Done: choice.FinishReason != ""})
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("%s stream read error: %w", g.Provider, err)
+	}
+
+	return full.String(), nil
+}
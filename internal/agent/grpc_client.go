@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	backendpb "Orkflow/internal/agent/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GRPCClient implements LLMClient (and StreamingClient) by dialing an
+// out-of-process backend over gRPC instead of calling a hosted HTTP API -
+// a local llama.cpp, vLLM, or whisper wrapper plugged in via ~/.orka.yaml's
+// backends: list (see BackendSpec) and referenced from a workflow the same
+// way any other model is, with provider: grpc and endpoint: its Addr.
+type GRPCClient struct {
+	Target string // e.g. "unix:///tmp/my-model.sock" or "tcp://localhost:50051"
+	Model  string
+
+	client backendpb.BackendClient
+}
+
+// dial lazily connects to Target the first time it's needed, reusing the
+// connection for every subsequent call.
+func (g *GRPCClient) dial() (backendpb.BackendClient, error) {
+	if g.client != nil {
+		return g.client, nil
+	}
+
+	target := strings.TrimPrefix(g.Target, "tcp://")
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("grpc backend %q: %w", g.Target, err)
+	}
+	g.client = backendpb.NewBackendClient(conn)
+	return g.client, nil
+}
+
+// Generate implements LLMClient, propagating ctx's cancellation/deadline
+// down into the backend's gRPC stream alongside the client's own
+// generation timeout.
+func (g *GRPCClient) Generate(ctx context.Context, prompt string) (string, error) {
+	return g.generate(ctx, prompt, nil)
+}
+
+// GenerateStream implements StreamingClient by draining the backend's
+// Generate stream, invoking onToken for each chunk as it arrives.
+func (g *GRPCClient) GenerateStream(prompt string, onToken func(Token)) (string, error) {
+	return g.generate(context.Background(), prompt, onToken)
+}
+
+// generate is shared by Generate and GenerateStream, deriving a
+// request-scoped deadline from ctx.
+func (g *GRPCClient) generate(ctx context.Context, prompt string, onToken func(Token)) (string, error) {
+	client, err := g.dial()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	stream, err := client.Generate(ctx, &backendpb.GenerateRequest{Prompt: prompt, Model: g.Model})
+	if err != nil {
+		return "", fmt.Errorf("grpc backend %q: %w", g.Target, err)
+	}
+
+	var full strings.Builder
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return full.String(), fmt.Errorf("grpc backend %q stream: %w", g.Target, err)
+		}
+
+		full.WriteString(chunk.Content)
+		if onToken != nil {
+			onToken(Token{Content: chunk.Content, Done: chunk.Done})
+		}
+		if chunk.Done {
+			break
+		}
+	}
+
+	return full.String(), nil
+}
+
+// Embed computes an embedding vector for text via the backend's Embed RPC,
+// so a grpc:// backend can also back vectorstore.Options{EmbeddingProvider:
+// "grpc"} instead of only text generation.
+func (g *GRPCClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	client, err := g.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Embed(ctx, &backendpb.EmbedRequest{Text: text, Model: g.Model})
+	if err != nil {
+		return nil, fmt.Errorf("grpc backend %q: %w", g.Target, err)
+	}
+	return resp.Values, nil
+}
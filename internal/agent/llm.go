@@ -1,7 +1,58 @@
 package agent
 
+import (
+	"context"
+	"encoding/json"
+
+	"Orkflow/internal/tools"
+)
+
 type LLMClient interface {
-	Generate(prompt string) (string, error)
+	// Generate takes ctx so a caller (the DAG executor's per-step timeout,
+	// an aborted run) can cancel an in-flight request rather than only
+	// refusing to start the next one.
+	Generate(ctx context.Context, prompt string) (string, error)
+}
+
+// Token is a single incremental chunk of generated text delivered by a
+// StreamingClient as it arrives from the provider.
+type Token struct {
+	Content string
+	Done    bool
+}
+
+// StreamingClient is an optional capability implemented by LLMClients that
+// support incremental token delivery. Callers should type-assert for it
+// rather than requiring every LLMClient to implement streaming, since not
+// every provider API supports it equally well.
+type StreamingClient interface {
+	GenerateStream(prompt string, onToken func(Token)) (string, error)
+}
+
+// ToolCallingClient is an optional capability implemented by LLMClients
+// that can constrain generation to one of a set of tool calls - native
+// function/tool request fields for hosted APIs, a GBNF grammar for local
+// models. Callers type-assert for it the same way they do for
+// StreamingClient, since not every provider supports either path.
+//
+// Implementations return a plain {"name": ..., "arguments": {...}} JSON
+// string that tools.ParseStructuredToolCall can decode - never the raw
+// provider response shape - so Runner.RunAgent doesn't need to know which
+// provider produced it.
+type ToolCallingClient interface {
+	GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error)
+}
+
+// encodeStructuredCall marshals name/arguments into the {"name",
+// "arguments"} JSON shape tools.ParseStructuredToolCall expects - the
+// common return value every ToolCallingClient implementation produces
+// once it finds a tool call in the provider's native response.
+func encodeStructuredCall(name string, arguments json.RawMessage) string {
+	out, _ := json.Marshal(struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}{Name: name, Arguments: arguments})
+	return string(out)
 }
 
 func NewLLMClient(provider string, model string, apiKey string, endpoint string) LLMClient {
@@ -30,6 +81,14 @@ func NewLLMClient(provider string, model string, apiKey string, endpoint string)
 			Endpoint: ep,
 			Model:    model,
 		}
+	case "grpc":
+		// endpoint is a unix:// or tcp:// target dialing an out-of-process
+		// backend plugged in via ~/.orka.yaml's backends: list - see
+		// GRPCClient and SpawnBackends.
+		return &GRPCClient{
+			Target: endpoint,
+			Model:  model,
+		}
 	default:
 		// Use generic OpenAI-compatible client for any other provider
 		// This auto-handles: groq, mistral, together, perplexity, openrouter, etc.
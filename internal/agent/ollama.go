@@ -1,13 +1,17 @@
 package agent
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
+
+	"Orkflow/internal/tools"
 )
 
 const OllamaTimeout = 3 * time.Minute // Max time for generation
@@ -17,17 +21,69 @@ type OllamaClient struct {
 	Model    string
 }
 
-func (o *OllamaClient) Generate(prompt string) (string, error) {
+func (o *OllamaClient) Generate(ctx context.Context, prompt string) (string, error) {
+	payload := map[string]interface{}{
+		"model":  o.Model,
+		"prompt": prompt,
+		"stream": false,
+	}
+
+	body, _ := json.Marshal(payload)
+	url := o.Endpoint + "/api/generate"
+
+	// Create request with timeout, derived from the caller's ctx so an
+	// aborted run or an outer deadline cancels this request too.
+	ctx, cancel := context.WithTimeout(ctx, OllamaTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("TIMEOUT: Ollama generation exceeded %v (try a faster model or shorter prompt)", OllamaTimeout)
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("ollama api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Response string `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.Response, nil
+}
+
+// GenerateWithTools implements ToolCallingClient by passing grammar as
+// Ollama's `grammar` constraint - Ollama has no native function-calling
+// fields, so toolSpecs is not sent; when grammar is empty the model just
+// answers unconstrained.
+func (o *OllamaClient) GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error) {
 	payload := map[string]interface{}{
 		"model":  o.Model,
 		"prompt": prompt,
 		"stream": false,
 	}
+	if grammar != "" {
+		payload["grammar"] = grammar
+	}
 
 	body, _ := json.Marshal(payload)
 	url := o.Endpoint + "/api/generate"
 
-	// Create request with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), OllamaTimeout)
 	defer cancel()
 
@@ -61,3 +117,78 @@ func (o *OllamaClient) Generate(prompt string) (string, error) {
 
 	return result.Response, nil
 }
+
+// GenerateStream implements StreamingClient by switching Ollama's
+// /api/generate endpoint to "stream": true and decoding the
+// newline-delimited JSON frames it returns, invoking onToken for each
+// incremental chunk as it arrives.
+func (o *OllamaClient) GenerateStream(prompt string, onToken func(Token)) (string, error) {
+	payload := map[string]interface{}{
+		"model":  o.Model,
+		"prompt": prompt,
+		"stream": true,
+	}
+
+	body, _ := json.Marshal(payload)
+	url := o.Endpoint + "/api/generate"
+
+	ctx, cancel := context.WithTimeout(context.Background(), OllamaTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("TIMEOUT: Ollama generation exceeded %v (try a faster model or shorter prompt)", OllamaTimeout)
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("ollama api error: %s", string(respBody))
+	}
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var frame struct {
+			Response string `json:"response"`
+			Done     bool   `json:"done"`
+		}
+		if err := json.Unmarshal(line, &frame); err != nil {
+			return full.String(), fmt.Errorf("failed to decode ollama stream frame: %w", err)
+		}
+
+		if frame.Response != "" {
+			full.WriteString(frame.Response)
+			if onToken != nil {
+				onToken(Token{Content: frame.Response, Done: frame.Done})
+			}
+		}
+
+		if frame.Done {
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return full.String(), fmt.Errorf("ollama stream read error: %w", err)
+	}
+
+	return full.String(), nil
+}
@@ -2,10 +2,13 @@ package agent
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+
+	"Orkflow/internal/tools"
 )
 
 type OpenAIClient struct {
@@ -13,7 +16,7 @@ type OpenAIClient struct {
 	Model  string
 }
 
-func (o *OpenAIClient) Generate(prompt string) (string, error) {
+func (o *OpenAIClient) Generate(ctx context.Context, prompt string) (string, error) {
 	payload := map[string]interface{}{
 		"model": o.Model,
 		"messages": []map[string]string{
@@ -22,7 +25,7 @@ func (o *OpenAIClient) Generate(prompt string) (string, error) {
 	}
 
 	body, _ := json.Marshal(payload)
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
 	if err != nil {
 		return "", err
 	}
@@ -58,3 +61,67 @@ func (o *OpenAIClient) Generate(prompt string) (string, error) {
 
 	return result.Choices[0].Message.Content, nil
 }
+
+// GenerateWithTools implements ToolCallingClient using OpenAI's native
+// `tools`/tool_choice request fields. grammar is ignored - OpenAI has no
+// grammar constraint, only structured function calling.
+func (o *OpenAIClient) GenerateWithTools(prompt string, toolSpecs []tools.ToolSpec, grammar string) (string, error) {
+	payload := map[string]interface{}{
+		"model": o.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if len(toolSpecs) > 0 {
+		payload["tools"] = toolSpecs
+		payload["tool_choice"] = "auto"
+	}
+
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("openai api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no response from openai")
+	}
+
+	msg := result.Choices[0].Message
+	if len(msg.ToolCalls) > 0 {
+		fn := msg.ToolCalls[0].Function
+		return encodeStructuredCall(fn.Name, json.RawMessage(fn.Arguments)), nil
+	}
+	return msg.Content, nil
+}
@@ -0,0 +1,315 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"Orkflow/internal/tools"
+	"Orkflow/pkg/types"
+)
+
+// PlanStep describes a single agent's place in the execution order.
+type PlanStep struct {
+	AgentID           string
+	Model             string
+	Group             int // agents sharing a Group run in parallel with each other
+	EstimatedLLMCalls int // base Generate call, plus one per expected tool follow-up
+}
+
+// PlanWarning flags a non-fatal issue that a reviewer should look at before
+// running the workflow for real (e.g. a required key nobody publishes).
+type PlanWarning struct {
+	AgentID string
+	Message string
+}
+
+// PlanResult is the structured report returned by Runner.Plan. It mirrors
+// what executeSequential/executeParallel/executeSupervisor would actually do,
+// without invoking any LLMClient or MCP server.
+type PlanResult struct {
+	ExecutionOrder []PlanStep
+	ParallelGroups [][]string
+	MCPServers     []string
+	Warnings       []PlanWarning
+}
+
+// Plan walks the configured agents and workflow, resolving Requires/Outputs
+// into a dependency graph, and returns a dry-run report: execution order,
+// parallel groups, estimated LLM calls per agent, MCP servers that would be
+// spawned, and warnings for problems that won't stop a run outright (e.g. an
+// agent requiring a key nobody produces). It returns an error for problems
+// that would make the workflow fail to even start (unknown model/tool/
+// sub-agent, or a Requires/Outputs cycle).
+func (r *Runner) Plan(ctx context.Context) (*PlanResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.validateReferences(); err != nil {
+		return nil, err
+	}
+
+	if cycle := r.findRequiresCycle(); cycle != nil {
+		return nil, fmt.Errorf("requires/outputs cycle detected: %v", cycle)
+	}
+
+	result := &PlanResult{
+		Warnings: r.findUnreachableRequires(),
+	}
+
+	for name := range r.Config.MCPServers {
+		result.MCPServers = append(result.MCPServers, name)
+	}
+	sort.Strings(result.MCPServers)
+
+	switch {
+	case r.Config.Workflow == nil:
+		result.ExecutionOrder, result.ParallelGroups = r.planSupervisor()
+	case r.Config.Workflow.Type == "parallel":
+		result.ExecutionOrder, result.ParallelGroups = r.planParallel()
+	default:
+		result.ExecutionOrder, result.ParallelGroups = r.planSequential()
+	}
+
+	return result, nil
+}
+
+func (r *Runner) planSequential() ([]PlanStep, [][]string) {
+	var order []PlanStep
+	group := 0
+	for _, step := range r.Config.Workflow.Steps {
+		order = append(order, r.planStepFor(step.Agent, group))
+		group++
+	}
+	return order, nil
+}
+
+func (r *Runner) planParallel() ([]PlanStep, [][]string) {
+	var order []PlanStep
+	group := 0
+
+	var branchGroup []string
+	for _, branchID := range r.Config.Workflow.Branches {
+		order = append(order, r.planStepFor(branchID, group))
+		branchGroup = append(branchGroup, branchID)
+	}
+	groups := [][]string{branchGroup}
+
+	if r.Config.Workflow.Then != nil {
+		group++
+		order = append(order, r.planStepFor(r.Config.Workflow.Then.Agent, group))
+	}
+
+	return order, groups
+}
+
+func (r *Runner) planSupervisor() ([]PlanStep, [][]string) {
+	var root *types.Agent
+	for i := range r.Config.Agents {
+		if r.Config.Agents[i].IsSupervisor() {
+			root = &r.Config.Agents[i]
+			break
+		}
+	}
+	if root == nil && len(r.Config.Agents) > 0 {
+		root = &r.Config.Agents[0]
+	}
+	if root == nil {
+		return nil, nil
+	}
+	return []PlanStep{r.planStepFor(root.ID, 0)}, nil
+}
+
+func (r *Runner) planStepFor(agentID string, group int) PlanStep {
+	agentDef := r.GetAgent(agentID)
+	step := PlanStep{AgentID: agentID, Group: group, EstimatedLLMCalls: 1}
+	if agentDef != nil {
+		step.Model = agentDef.Model
+		if len(agentDef.Tools) > 0 || len(agentDef.Toolsets) > 0 {
+			step.EstimatedLLMCalls = 2 // base call plus a possible tool follow-up
+		}
+	}
+	return step
+}
+
+// validateReferences checks that every model, tool, toolset, and sub-agent
+// referenced by the config actually exists, returning an error describing
+// the first one it finds.
+func (r *Runner) validateReferences() error {
+	for _, a := range r.Config.Agents {
+		if _, ok := r.Config.Models[a.Model]; !ok {
+			return fmt.Errorf("agent %s: model not found: %s", a.ID, a.Model)
+		}
+
+		if len(a.Tools) > 0 {
+			if _, err := tools.GetByNames(a.Tools); err != nil {
+				return fmt.Errorf("agent %s: %w", a.ID, err)
+			}
+		}
+
+		for _, toolset := range a.Toolsets {
+			if _, ok := r.Config.MCPServers[toolset]; !ok {
+				return fmt.Errorf("agent %s: toolset references unknown MCP server: %s", a.ID, toolset)
+			}
+		}
+
+		for _, subID := range a.SubAgents {
+			if r.GetAgent(subID) == nil {
+				return fmt.Errorf("agent %s: sub-agent not found: %s", a.ID, subID)
+			}
+		}
+	}
+
+	if r.Config.Workflow != nil {
+		for _, step := range r.Config.Workflow.Steps {
+			if r.GetAgent(step.Agent) == nil {
+				return fmt.Errorf("workflow step: agent not found: %s", step.Agent)
+			}
+		}
+		for _, branchID := range r.Config.Workflow.Branches {
+			if r.GetAgent(branchID) == nil {
+				return fmt.Errorf("workflow branch: agent not found: %s", branchID)
+			}
+		}
+		if r.Config.Workflow.Then != nil && r.GetAgent(r.Config.Workflow.Then.Agent) == nil {
+			return fmt.Errorf("workflow then: agent not found: %s", r.Config.Workflow.Then.Agent)
+		}
+	}
+
+	return nil
+}
+
+// findRequiresCycle builds the producer(Outputs) -> consumer(Requires) graph
+// over shared-memory keys and returns the agent IDs forming a cycle, or nil
+// if the graph is acyclic.
+func (r *Runner) findRequiresCycle() []string {
+	producers := make(map[string][]string) // key -> agent IDs that publish it
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Outputs {
+			producers[key] = append(producers[key], a.ID)
+		}
+	}
+
+	edges := make(map[string][]string) // agent ID -> agent IDs it depends on
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Requires {
+			edges[a.ID] = append(edges[a.ID], producers[key]...)
+		}
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, dep := range edges[id] {
+			switch state[dep] {
+			case visiting:
+				return append(append([]string{}, path...), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, a := range r.Config.Agents {
+		if state[a.ID] == unvisited {
+			if cycle := visit(a.ID); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// findDAGCycle builds the depends_on graph over a "dag" workflow's steps and
+// returns the agent IDs forming a cycle, or nil if it's acyclic. A cycle
+// here isn't just invalid - executeDAG's step goroutines each block waiting
+// for their dependencies to finish, so an undetected cycle hangs the run
+// forever instead of failing.
+func (r *Runner) findDAGCycle() []string {
+	edges := make(map[string][]string, len(r.Config.Workflow.Steps)) // agent ID -> its depends_on IDs
+	for _, step := range r.Config.Workflow.Steps {
+		edges[step.Agent] = step.DependsOn
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, dep := range edges[id] {
+			switch state[dep] {
+			case visiting:
+				return append(append([]string{}, path...), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, step := range r.Config.Workflow.Steps {
+		if state[step.Agent] == unvisited {
+			if cycle := visit(step.Agent); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// findUnreachableRequires warns about any agent that requires a shared-memory
+// key that no agent in the workflow ever publishes.
+func (r *Runner) findUnreachableRequires() []PlanWarning {
+	produced := make(map[string]bool)
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Outputs {
+			produced[key] = true
+		}
+	}
+
+	var warnings []PlanWarning
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Requires {
+			if !produced[key] {
+				warnings = append(warnings, PlanWarning{
+					AgentID: a.ID,
+					Message: fmt.Sprintf("requires key %q but no agent publishes it", key),
+				})
+			}
+		}
+	}
+
+	return warnings
+}
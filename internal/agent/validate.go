@@ -0,0 +1,204 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"Orkflow/internal/tools"
+)
+
+// IssueSeverity classifies a ValidationIssue as fatal or advisory.
+type IssueSeverity string
+
+const (
+	SeverityError   IssueSeverity = "error"
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// ValidationIssue is a single problem found while validating a workflow
+// config: a missing field, a dangling reference, an unreachable key, or a
+// dependency cycle.
+type ValidationIssue struct {
+	Severity IssueSeverity
+	AgentID  string // empty when the issue isn't scoped to one agent
+	Message  string
+}
+
+func (i ValidationIssue) String() string {
+	if i.AgentID == "" {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s: agent %s: %s", i.Severity, i.AgentID, i.Message)
+}
+
+// ValidationResult is the full set of issues found by Runner.Validate.
+type ValidationResult struct {
+	Issues []ValidationIssue
+}
+
+// Errors returns only the fatal issues.
+func (v *ValidationResult) Errors() []ValidationIssue {
+	return v.filter(SeverityError)
+}
+
+// Warnings returns only the advisory issues.
+func (v *ValidationResult) Warnings() []ValidationIssue {
+	return v.filter(SeverityWarning)
+}
+
+func (v *ValidationResult) filter(sev IssueSeverity) []ValidationIssue {
+	var out []ValidationIssue
+	for _, issue := range v.Issues {
+		if issue.Severity == sev {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// Validate runs structural, reference, and DAG checks over the configured
+// workflow, collecting every issue it finds rather than stopping at the
+// first one (unlike Plan, which is meant to fail fast before a run). Fatal
+// problems (missing required fields, dangling references, dependency
+// cycles) are reported as errors; cosmetic problems (an agent nobody calls,
+// a shared-memory key nobody waits on) are reported as warnings.
+func (r *Runner) Validate() *ValidationResult {
+	result := &ValidationResult{}
+	add := func(sev IssueSeverity, agentID, format string, args ...interface{}) {
+		result.Issues = append(result.Issues, ValidationIssue{
+			Severity: sev,
+			AgentID:  agentID,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, a := range r.Config.Agents {
+		if a.ID == "" {
+			add(SeverityError, "", "agent has no id")
+			continue
+		}
+		if a.Model == "" {
+			add(SeverityError, a.ID, "missing required field: model")
+		} else if _, ok := r.Config.Models[a.Model]; !ok {
+			add(SeverityError, a.ID, "model not found: %s", a.Model)
+		}
+
+		for _, toolName := range a.Tools {
+			if _, ok := tools.Get(toolName); !ok {
+				add(SeverityError, a.ID, "unknown tool: %s", toolName)
+			}
+		}
+
+		for _, toolset := range a.Toolsets {
+			if _, ok := r.Config.MCPServers[toolset]; !ok {
+				add(SeverityError, a.ID, "toolset references unknown MCP server: %s", toolset)
+			}
+		}
+
+		for _, subID := range a.SubAgents {
+			if r.GetAgent(subID) == nil {
+				add(SeverityError, a.ID, "sub-agent not found: %s", subID)
+			}
+		}
+	}
+
+	if r.Config.Workflow != nil {
+		for _, step := range r.Config.Workflow.Steps {
+			if r.GetAgent(step.Agent) == nil {
+				add(SeverityError, "", "workflow step references unknown agent: %s", step.Agent)
+			}
+		}
+		for _, branchID := range r.Config.Workflow.Branches {
+			if r.GetAgent(branchID) == nil {
+				add(SeverityError, "", "workflow branch references unknown agent: %s", branchID)
+			}
+		}
+		if r.Config.Workflow.Then != nil && r.GetAgent(r.Config.Workflow.Then.Agent) == nil {
+			add(SeverityError, "", "workflow then references unknown agent: %s", r.Config.Workflow.Then.Agent)
+		}
+	}
+
+	if cycle := r.findRequiresCycle(); cycle != nil {
+		add(SeverityError, "", "requires/outputs cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	if r.Config.Workflow != nil && r.Config.Workflow.Type == "dag" {
+		if cycle := r.findDAGCycle(); cycle != nil {
+			add(SeverityError, "", "dag depends_on cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	for _, w := range r.findUnreachableRequires() {
+		add(SeverityWarning, w.AgentID, w.Message)
+	}
+
+	for _, agentID := range r.findUnusedAgents() {
+		add(SeverityWarning, agentID, "agent is never referenced by a workflow step, branch, then, or sub_agents list")
+	}
+
+	for _, key := range r.findUnusedOutputs() {
+		add(SeverityWarning, "", "shared-memory key %q is published but never required by any agent", key)
+	}
+
+	return result
+}
+
+// findUnusedAgents returns the IDs of agents that nothing in the workflow
+// ever invokes: not a sequential step, not a parallel branch or then, not a
+// supervisor's sub-agent, and not the supervisor root itself.
+func (r *Runner) findUnusedAgents() []string {
+	referenced := make(map[string]bool)
+
+	for _, a := range r.Config.Agents {
+		for _, subID := range a.SubAgents {
+			referenced[subID] = true
+		}
+		if a.IsSupervisor() {
+			referenced[a.ID] = true
+		}
+	}
+
+	if r.Config.Workflow != nil {
+		for _, step := range r.Config.Workflow.Steps {
+			referenced[step.Agent] = true
+		}
+		for _, branchID := range r.Config.Workflow.Branches {
+			referenced[branchID] = true
+		}
+		if r.Config.Workflow.Then != nil {
+			referenced[r.Config.Workflow.Then.Agent] = true
+		}
+	} else if len(r.Config.Agents) > 0 {
+		// No workflow block: the first agent is the implicit supervisor root.
+		referenced[r.Config.Agents[0].ID] = true
+	}
+
+	var unused []string
+	for _, a := range r.Config.Agents {
+		if !referenced[a.ID] {
+			unused = append(unused, a.ID)
+		}
+	}
+	return unused
+}
+
+// findUnusedOutputs returns shared-memory keys that some agent publishes but
+// no agent ever requires.
+func (r *Runner) findUnusedOutputs() []string {
+	required := make(map[string]bool)
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Requires {
+			required[key] = true
+		}
+	}
+
+	var unused []string
+	for _, a := range r.Config.Agents {
+		for _, key := range a.Outputs {
+			if !required[key] {
+				unused = append(unused, key)
+			}
+		}
+	}
+	return unused
+}
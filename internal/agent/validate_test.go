@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"Orkflow/pkg/types"
+)
+
+func hasError(result *ValidationResult, substr string) bool {
+	for _, issue := range result.Errors() {
+		if strings.Contains(issue.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateMissingModel(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{{ID: "a"}},
+	}
+	result := NewRunner(config).Validate()
+
+	if !hasError(result, "missing required field: model") {
+		t.Errorf("Errors() = %v, want a missing-model error", result.Errors())
+	}
+}
+
+func TestValidateUnknownModel(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{{ID: "a", Model: "does-not-exist"}},
+	}
+	result := NewRunner(config).Validate()
+
+	if !hasError(result, "model not found: does-not-exist") {
+		t.Errorf("Errors() = %v, want an unknown-model error", result.Errors())
+	}
+}
+
+func TestValidateDanglingWorkflowStep(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{{ID: "a", Model: "m"}},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+		Workflow: &types.WorkflowSpec{
+			Type:  "sequential",
+			Steps: []types.Step{{Agent: "does-not-exist"}},
+		},
+	}
+	result := NewRunner(config).Validate()
+
+	if !hasError(result, "workflow step references unknown agent: does-not-exist") {
+		t.Errorf("Errors() = %v, want a dangling-step error", result.Errors())
+	}
+}
+
+func TestValidateCleanConfigHasNoErrors(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{{ID: "a", Model: "m"}},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+	}
+	result := NewRunner(config).Validate()
+
+	if errs := result.Errors(); len(errs) != 0 {
+		t.Errorf("Errors() = %v, want none", errs)
+	}
+}
+
+func TestValidateDAGDependsOnCycle(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{
+			{ID: "a", Model: "m"},
+			{ID: "b", Model: "m"},
+		},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+		Workflow: &types.WorkflowSpec{
+			Type: "dag",
+			Steps: []types.Step{
+				{Agent: "a", DependsOn: []string{"b"}},
+				{Agent: "b", DependsOn: []string{"a"}},
+			},
+		},
+	}
+	result := NewRunner(config).Validate()
+
+	if !hasError(result, "dag depends_on cycle detected") {
+		t.Errorf("Errors() = %v, want a dag cycle error", result.Errors())
+	}
+}
+
+func TestValidateDAGAcyclicHasNoCycleError(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{
+			{ID: "a", Model: "m"},
+			{ID: "b", Model: "m"},
+		},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+		Workflow: &types.WorkflowSpec{
+			Type: "dag",
+			Steps: []types.Step{
+				{Agent: "a"},
+				{Agent: "b", DependsOn: []string{"a"}},
+			},
+		},
+	}
+	result := NewRunner(config).Validate()
+
+	if hasError(result, "dag depends_on cycle detected") {
+		t.Errorf("Errors() = %v, want no dag cycle error", result.Errors())
+	}
+}
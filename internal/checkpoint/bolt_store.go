@@ -0,0 +1,109 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.etcd.io/bbolt"
+)
+
+var checkpointsBucket = []byte("checkpoints")
+
+// BoltStore persists checkpoints in a single BoltDB file, keyed by run ID.
+// Preferred over FSStore when many runs are checkpointed concurrently, since
+// it avoids one-file-per-run directory churn.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB database at path. An
+// empty path defaults to ~/.orka/checkpoints.db.
+func NewBoltStore(path string) (*BoltStore, error) {
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, ".orka", "checkpoints.db")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint db: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(checkpointsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(checkpointsBucket).Put([]byte(cp.RunID), data)
+	})
+}
+
+func (s *BoltStore) Load(runID string) (*Checkpoint, error) {
+	var cp Checkpoint
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(checkpointsBucket).Get([]byte(runID))
+		if data == nil {
+			return fmt.Errorf("checkpoint not found: %s", runID)
+		}
+		return json.Unmarshal(data, &cp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (s *BoltStore) List() ([]*Checkpoint, error) {
+	var checkpoints []*Checkpoint
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(checkpointsBucket).ForEach(func(_, data []byte) error {
+			var cp Checkpoint
+			if err := json.Unmarshal(data, &cp); err != nil {
+				return err
+			}
+			checkpoints = append(checkpoints, &cp)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].UpdatedAt.After(checkpoints[j].UpdatedAt)
+	})
+
+	return checkpoints, nil
+}
+
+func (s *BoltStore) Delete(runID string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(checkpointsBucket).Delete([]byte(runID))
+	})
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
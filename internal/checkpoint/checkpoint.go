@@ -0,0 +1,76 @@
+// Package checkpoint persists in-flight workflow state so a crashed or
+// killed run can be resumed without re-invoking already-completed (and
+// expensive) LLM calls.
+package checkpoint
+
+import (
+	"time"
+)
+
+// AgentStatus is the lifecycle state of a single agent within a checkpointed
+// run.
+type AgentStatus string
+
+const (
+	AgentPending   AgentStatus = "pending"
+	AgentRunning   AgentStatus = "running"
+	AgentCompleted AgentStatus = "completed"
+	// AgentLost marks an agent that was running when the checkpoint was last
+	// written but never reported completion, analogous to a Nomad lost
+	// allocation. Resume re-enqueues lost agents.
+	AgentLost AgentStatus = "lost"
+)
+
+// Checkpoint is a point-in-time snapshot of a workflow run: its DAG
+// position, the outputs produced so far, shared-memory state, and any
+// Requires waits still in flight.
+type Checkpoint struct {
+	RunID           string                 `json:"run_id"`
+	Workflow        string                 `json:"workflow"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	Status          string                 `json:"status"` // mirrors engine.WorkflowState.String()
+	CurrentStep     int                    `json:"current_step"`
+	TotalSteps      int                    `json:"total_steps"`
+	AgentStatus     map[string]AgentStatus `json:"agent_status"`
+	Outputs         map[string]string      `json:"outputs"` // agent ID -> last response
+	SharedMemory    map[string]interface{} `json:"shared_memory"`
+	PendingRequires map[string][]string    `json:"pending_requires"` // agent ID -> keys still awaited
+	Error           string                 `json:"error,omitempty"`
+}
+
+// New creates an empty checkpoint for a fresh run.
+func New(runID, workflow string, totalSteps int) *Checkpoint {
+	now := time.Now()
+	return &Checkpoint{
+		RunID:           runID,
+		Workflow:        workflow,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Status:          "pending",
+		TotalSteps:      totalSteps,
+		AgentStatus:     make(map[string]AgentStatus),
+		Outputs:         make(map[string]string),
+		SharedMemory:    make(map[string]interface{}),
+		PendingRequires: make(map[string][]string),
+	}
+}
+
+// MarkLostInFlight flips every agent still Running to Lost, so Resume knows
+// which ones to re-enqueue instead of trusting a stale "running" status left
+// behind by a crash.
+func (c *Checkpoint) MarkLostInFlight() {
+	for id, status := range c.AgentStatus {
+		if status == AgentRunning {
+			c.AgentStatus[id] = AgentLost
+		}
+	}
+}
+
+// Store is a pluggable persistence backend for checkpoints.
+type Store interface {
+	Save(cp *Checkpoint) error
+	Load(runID string) (*Checkpoint, error)
+	List() ([]*Checkpoint, error)
+	Delete(runID string) error
+}
@@ -0,0 +1,91 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const CheckpointsFolder = ".orka/checkpoints"
+
+// FSStore persists each checkpoint as its own JSON file under
+// ~/.orka/checkpoints/<runID>.json.
+type FSStore struct {
+	dir string
+}
+
+// NewFSStore creates a filesystem-backed Store. An empty dir defaults to
+// ~/.orka/checkpoints.
+func NewFSStore(dir string) *FSStore {
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, CheckpointsFolder)
+	}
+	return &FSStore{dir: dir}
+}
+
+func (s *FSStore) path(runID string) string {
+	return filepath.Join(s.dir, runID+".json")
+}
+
+func (s *FSStore) Save(cp *Checkpoint) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(cp.RunID), data, 0644)
+}
+
+func (s *FSStore) Load(runID string) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path(runID))
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint not found: %s: %w", runID, err)
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (s *FSStore) List() ([]*Checkpoint, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Checkpoint{}, nil
+		}
+		return nil, err
+	}
+
+	var checkpoints []*Checkpoint
+	for _, f := range files {
+		if filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+
+		runID := f.Name()[:len(f.Name())-len(".json")]
+		cp, err := s.Load(runID)
+		if err != nil {
+			continue
+		}
+		checkpoints = append(checkpoints, cp)
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].UpdatedAt.After(checkpoints[j].UpdatedAt)
+	})
+
+	return checkpoints, nil
+}
+
+func (s *FSStore) Delete(runID string) error {
+	return os.Remove(s.path(runID))
+}
@@ -0,0 +1,87 @@
+package checkpoint
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFSStoreSaveLoadRoundTrip(t *testing.T) {
+	store := NewFSStore(t.TempDir())
+
+	cp := New("run-1", "workflow.yaml", 3)
+	cp.AgentStatus["a"] = AgentCompleted
+	cp.Outputs["a"] = "result"
+
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load("run-1")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.RunID != "run-1" || loaded.Outputs["a"] != "result" || loaded.AgentStatus["a"] != AgentCompleted {
+		t.Errorf("Load() = %+v, want round-tripped fields from Save", loaded)
+	}
+}
+
+func TestFSStoreLoadMissing(t *testing.T) {
+	store := NewFSStore(t.TempDir())
+
+	if _, err := store.Load("does-not-exist"); err == nil {
+		t.Error("Load() error = nil for a checkpoint that was never saved, want an error")
+	}
+}
+
+func TestFSStoreListOrdersByUpdatedAtDescending(t *testing.T) {
+	store := NewFSStore(t.TempDir())
+
+	older := New("run-older", "workflow.yaml", 1)
+	newer := New("run-newer", "workflow.yaml", 1)
+	newer.UpdatedAt = older.UpdatedAt.Add(1)
+
+	if err := store.Save(older); err != nil {
+		t.Fatalf("Save(older) error = %v", err)
+	}
+	if err := store.Save(newer); err != nil {
+		t.Fatalf("Save(newer) error = %v", err)
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].RunID != "run-newer" {
+		t.Errorf("List() = %+v, want run-newer first", list)
+	}
+}
+
+func TestFSStoreDelete(t *testing.T) {
+	store := NewFSStore(t.TempDir())
+	cp := New("run-1", "workflow.yaml", 1)
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if err := store.Delete("run-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Load("run-1"); !os.IsNotExist(err) && err == nil {
+		t.Error("Load() after Delete() = nil error, want the file to be gone")
+	}
+}
+
+func TestMarkLostInFlight(t *testing.T) {
+	cp := New("run-1", "workflow.yaml", 2)
+	cp.AgentStatus["running-agent"] = AgentRunning
+	cp.AgentStatus["done-agent"] = AgentCompleted
+
+	cp.MarkLostInFlight()
+
+	if cp.AgentStatus["running-agent"] != AgentLost {
+		t.Errorf("AgentStatus[running-agent] = %s, want %s", cp.AgentStatus["running-agent"], AgentLost)
+	}
+	if cp.AgentStatus["done-agent"] != AgentCompleted {
+		t.Errorf("AgentStatus[done-agent] = %s, want unchanged %s", cp.AgentStatus["done-agent"], AgentCompleted)
+	}
+}
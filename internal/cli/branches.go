@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"Orkflow/internal/memory"
+
+	"github.com/spf13/cobra"
+)
+
+var branchesDiffB string
+
+var branchesCmd = &cobra.Command{
+	Use:   "branches <session-id>",
+	Short: "List and diff a session's branches",
+	Long: `List the branches forked from a session with 'orka run --edit', or
+diff two of them message-by-message.
+
+Examples:
+  orka branches abc123
+  orka branches abc123 --diff main,a1b2c3d4`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		session, err := memory.LoadSession(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading session: %v\n", err)
+			os.Exit(1)
+		}
+
+		if branchesDiffB != "" {
+			runBranchesDiff(session, branchesDiffB)
+			return
+		}
+
+		branches := session.ListBranches()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tACTIVE\tMESSAGES\tCREATED")
+		fmt.Fprintln(w, "--\t----\t------\t--------\t-------")
+		for _, b := range branches {
+			active := ""
+			if b.ID == session.ActiveBranch {
+				active = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+				b.ID, b.Name, active, len(session.BranchHistory(b.ID)), b.CreatedAt.Format(time.Stamp))
+		}
+		w.Flush()
+	},
+}
+
+func runBranchesDiff(session *memory.Session, spec string) {
+	var ids []string
+	for _, id := range strings.Split(spec, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) != 2 {
+		fmt.Fprintf(os.Stderr, "Error: --diff expects exactly two branch IDs separated by a comma, got %q\n", spec)
+		os.Exit(1)
+	}
+
+	left := session.BranchHistory(ids[0])
+	right := session.BranchHistory(ids[1])
+	if len(left) == 0 && len(right) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: neither branch %q nor %q has any messages\n", ids[0], ids[1])
+		os.Exit(1)
+	}
+
+	commonLen := len(left)
+	if len(right) < commonLen {
+		commonLen = len(right)
+	}
+	divergeAt := commonLen
+	for i := 0; i < commonLen; i++ {
+		if left[i].ID != right[i].ID {
+			divergeAt = i
+			break
+		}
+	}
+
+	fmt.Printf("Shared history: %d message(s)\n\n", divergeAt)
+	fmt.Printf("--- %s\n", ids[0])
+	for _, msg := range left[divergeAt:] {
+		fmt.Printf("  [%s] %s: %s\n", msg.ID, msg.AgentID, truncateStr(msg.Content, 80))
+	}
+	fmt.Printf("+++ %s\n", ids[1])
+	for _, msg := range right[divergeAt:] {
+		fmt.Printf("  [%s] %s: %s\n", msg.ID, msg.AgentID, truncateStr(msg.Content, 80))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+	branchesCmd.Flags().StringVar(&branchesDiffB, "diff", "", "Diff two branch IDs, comma-separated (e.g. main,a1b2c3d4)")
+}
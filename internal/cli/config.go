@@ -8,26 +8,34 @@ import (
 	"os"
 	"path/filepath"
 
+	"Orkflow/internal/agent"
+
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the orka configuration
 type Config struct {
-	APIKey   string `yaml:"api_key,omitempty"`
-	Name     string `yaml:"name,omitempty"`
-	Model    string `yaml:"model,omitempty"`
-	Provider string `yaml:"provider,omitempty"`
+	APIKey            string              `yaml:"api_key,omitempty"`
+	Name              string              `yaml:"name,omitempty"`
+	Model             string              `yaml:"model,omitempty"`
+	Provider          string              `yaml:"provider,omitempty"`
+	SessionStore      string              `yaml:"session_store,omitempty"`
+	Backends          []agent.BackendSpec `yaml:"backends,omitempty"`
+	EmbeddingProvider string              `yaml:"embedding_provider,omitempty"`
+	EmbeddingModel    string              `yaml:"embedding_model,omitempty"`
 }
 
 var (
-	apiKey   string
-	name     string
-	model    string
-	provider string
-	show     bool
-	global   bool
-	local    bool
+	apiKey            string
+	name              string
+	model             string
+	provider          string
+	embeddingProvider string
+	embeddingModel    string
+	show              bool
+	global            bool
+	local             bool
 )
 
 // configCmd represents the config command
@@ -59,15 +67,17 @@ Examples:
 		}
 
 		// Check if at least one config flag is provided
-		if apiKey == "" && name == "" && model == "" && provider == "" {
+		if apiKey == "" && name == "" && model == "" && provider == "" && embeddingProvider == "" && embeddingModel == "" {
 			fmt.Println("Error: No configuration option provided.")
 			fmt.Println()
 			fmt.Println("Available options:")
-			fmt.Println("  --api <key>        Set API key")
-			fmt.Println("  --name <name>      Set project name")
-			fmt.Println("  --model <model>    Set default model")
-			fmt.Println("  --provider <name>  Set default provider")
-			fmt.Println("  --show             Show current configuration")
+			fmt.Println("  --api <key>                Set API key")
+			fmt.Println("  --name <name>              Set project name")
+			fmt.Println("  --model <model>            Set default model")
+			fmt.Println("  --provider <name>          Set default provider")
+			fmt.Println("  --embedding-provider <name> Set default embedding backend (ollama, openai, mistral, gemini, cohere, huggingface)")
+			fmt.Println("  --embedding-model <name>   Set default embedding model")
+			fmt.Println("  --show                     Show current configuration")
 			fmt.Println()
 			fmt.Println("Scope options:")
 			fmt.Println("  --global           Save to ~/.orka.yaml (default)")
@@ -99,6 +109,14 @@ Examples:
 			config.Provider = provider
 			fmt.Printf("✓ Provider set to: %s\n", provider)
 		}
+		if embeddingProvider != "" {
+			config.EmbeddingProvider = embeddingProvider
+			fmt.Printf("✓ Embedding provider set to: %s\n", embeddingProvider)
+		}
+		if embeddingModel != "" {
+			config.EmbeddingModel = embeddingModel
+			fmt.Printf("✓ Embedding model set to: %s\n", embeddingModel)
+		}
 
 		// Save config
 		if err := saveConfig(configPath, config); err != nil {
@@ -121,6 +139,8 @@ func init() {
 	configCmd.Flags().StringVar(&name, "name", "", "Project name")
 	configCmd.Flags().StringVar(&model, "model", "", "Default AI model to use")
 	configCmd.Flags().StringVar(&provider, "provider", "", "AI provider (openai, anthropic, etc.)")
+	configCmd.Flags().StringVar(&embeddingProvider, "embedding-provider", "", "Default embedding backend (ollama, openai, mistral, gemini, cohere, huggingface)")
+	configCmd.Flags().StringVar(&embeddingModel, "embedding-model", "", "Default embedding model")
 	configCmd.Flags().BoolVar(&show, "show", false, "Show current configuration")
 	configCmd.Flags().BoolVar(&global, "global", false, "Use global config (~/.orka.yaml)")
 	configCmd.Flags().BoolVar(&local, "local", false, "Use local config (./.orka.yaml)")
@@ -176,10 +196,14 @@ func LoadEffectiveConfig() *Config {
 
 	// Merge: local overrides global
 	effective := &Config{
-		APIKey:   globalConfig.APIKey,
-		Name:     globalConfig.Name,
-		Model:    globalConfig.Model,
-		Provider: globalConfig.Provider,
+		APIKey:            globalConfig.APIKey,
+		Name:              globalConfig.Name,
+		Model:             globalConfig.Model,
+		Provider:          globalConfig.Provider,
+		SessionStore:      globalConfig.SessionStore,
+		Backends:          globalConfig.Backends,
+		EmbeddingProvider: globalConfig.EmbeddingProvider,
+		EmbeddingModel:    globalConfig.EmbeddingModel,
 	}
 
 	if localConfig.APIKey != "" {
@@ -194,6 +218,18 @@ func LoadEffectiveConfig() *Config {
 	if localConfig.Provider != "" {
 		effective.Provider = localConfig.Provider
 	}
+	if localConfig.SessionStore != "" {
+		effective.SessionStore = localConfig.SessionStore
+	}
+	if len(localConfig.Backends) > 0 {
+		effective.Backends = localConfig.Backends
+	}
+	if localConfig.EmbeddingProvider != "" {
+		effective.EmbeddingProvider = localConfig.EmbeddingProvider
+	}
+	if localConfig.EmbeddingModel != "" {
+		effective.EmbeddingModel = localConfig.EmbeddingModel
+	}
 
 	return effective
 }
@@ -258,4 +294,22 @@ func printConfig(config *Config) {
 	} else {
 		fmt.Println("Provider: (not set)")
 	}
+
+	if config.SessionStore != "" {
+		fmt.Printf("Session Store: %s\n", config.SessionStore)
+	} else {
+		fmt.Println("Session Store: (not set, defaults to file)")
+	}
+
+	if config.EmbeddingProvider != "" {
+		fmt.Printf("Embedding Provider: %s\n", config.EmbeddingProvider)
+	} else {
+		fmt.Println("Embedding Provider: (not set, defaults to ollama)")
+	}
+
+	if config.EmbeddingModel != "" {
+		fmt.Printf("Embedding Model: %s\n", config.EmbeddingModel)
+	} else {
+		fmt.Println("Embedding Model: (not set, defaults per-provider)")
+	}
 }
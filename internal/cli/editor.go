@@ -0,0 +1,58 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"golang.org/x/term"
+)
+
+// isTerminal reports whether stdout is an interactive terminal, so
+// `--tui` can fall back to plain output when piped or redirected.
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// editInEditor writes content to a temp file, opens it in $EDITOR (falling
+// back to vi), waits for the editor to exit, then returns the file's final
+// contents. Used by --edit to let a user rewrite a session message before
+// forking a new branch from it.
+func editInEditor(content string) (string, error) {
+	tmp, err := os.CreateTemp("", "orka-edit-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := tmp.Name()
+	defer os.Remove(path)
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q exited with error: %w", editor, err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"Orkflow/internal/agent"
+	"Orkflow/internal/parser"
+
+	"github.com/spf13/cobra"
+)
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan <workflow.yaml>",
+	Short: "Show the execution plan for a workflow without running it",
+	Long: `Plan resolves a workflow's agents, models, tools, and shared-memory
+dependencies into a dry-run report: execution order, parallel groups,
+estimated LLM calls per agent, and MCP servers that would be spawned. No
+provider is ever invoked.
+
+Exit codes mirror terraform/nomad plan:
+  0   plan is valid with no warnings
+  1   plan is invalid (unknown model/tool/sub-agent, or a requires/outputs cycle)
+  2   plan is valid but has warnings (e.g. a required key nobody publishes)
+
+Examples:
+  orka plan workflow.yaml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowFile := args[0]
+
+		config, err := parser.ParseYAML(workflowFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing workflow: %v\n", err)
+			os.Exit(1)
+		}
+
+		runner := agent.NewRunner(config)
+		result, err := runner.Plan(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Execution order:")
+		for _, step := range result.ExecutionOrder {
+			fmt.Printf("  [group %d] %s (model: %s, ~%d LLM call(s))\n", step.Group, step.AgentID, step.Model, step.EstimatedLLMCalls)
+		}
+
+		if len(result.ParallelGroups) > 0 {
+			fmt.Println("\nParallel groups:")
+			for i, group := range result.ParallelGroups {
+				fmt.Printf("  %d: %v\n", i, group)
+			}
+		}
+
+		if len(result.MCPServers) > 0 {
+			fmt.Println("\nMCP servers to spawn:")
+			for _, name := range result.MCPServers {
+				fmt.Printf("  - %s\n", name)
+			}
+		}
+
+		if len(result.Warnings) > 0 {
+			fmt.Println("\nWarnings:")
+			for _, w := range result.Warnings {
+				fmt.Printf("  - %s: %s\n", w.AgentID, w.Message)
+			}
+			os.Exit(2)
+		}
+
+		fmt.Println("\nPlan is valid.")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
@@ -7,11 +7,14 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"Orkflow/internal/logging"
 )
 
 var (
-	cfgFile string
-	verbose bool
+	cfgFile          string
+	verbose          bool
+	sessionStoreFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -24,6 +27,7 @@ to define, validate, and execute workflows using YAML configuration files.
 Examples:
   orka run workflow.yaml        Run a workflow
   orka validate workflow.yaml   Validate a workflow file
+  orka plan workflow.yaml       Show the execution plan without running it
   orka --help                   Show this help message`,
 }
 
@@ -40,4 +44,8 @@ func init() {
 	// Global flags available to all commands
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.orka.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&sessionStoreFlag, "session-store", "", "session storage backend: file (default), bolt, or redis://host:port")
+
+	cobra.OnInitialize(initSessionStore)
+	cobra.OnInitialize(func() { logging.SetVerbose(verbose) })
 }
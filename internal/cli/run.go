@@ -6,13 +6,19 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
+	"Orkflow/internal/agent"
+	"Orkflow/internal/checkpoint"
+	"Orkflow/internal/cli/tui"
 	"Orkflow/internal/engine"
 	"Orkflow/internal/logging"
 	"Orkflow/internal/memory"
+	"Orkflow/internal/metrics"
 	"Orkflow/internal/parser"
+	"Orkflow/internal/pricing"
 	"Orkflow/internal/vectorstore"
 	"Orkflow/pkg/types"
 
@@ -20,13 +26,27 @@ import (
 )
 
 var (
-	sessionID      string
-	continueLatest bool
-	userPrompt     string
-	useProvider    string
-	useModel       string
-	smartContext   bool
-	enableLogging  bool
+	sessionID        string
+	continueLatest   bool
+	userPrompt       string
+	useProvider      string
+	useModel         string
+	smartContext     bool
+	enableLogging    bool
+	logFormat        string
+	logServeAddr     string
+	metricsAddr      string
+	metricsToken     string
+	enableCheckpoint bool
+	pricingFile      string
+	editMsgID        string
+	branchName       string
+	tuiMode          bool
+	promptEditor     bool
+	embedProvider    string
+	embedModel       string
+	resumeFromStep   string
+	replayOnly       bool
 )
 
 var runCmd = &cobra.Command{
@@ -41,21 +61,42 @@ Session Options:
   --session <id>    Continue a specific session
   --continue        Continue the most recent session
   --prompt <text>   Provide input prompt for the session
+  --prompt-editor   Compose the prompt in $EDITOR instead of passing --prompt
   --smart-context   Auto-inject relevant context from past sessions (requires Ollama)
+  --edit <msg-id>   Edit a past message in $EDITOR and fork a new branch from it
+  --branch <name>   With --edit, name the forked branch; alone, check out an existing branch
+  --tui             Interactive TUI with live per-agent panes (falls back to plain output off-TTY)
 
 Model Override:
   --use-provider    Override provider for all agents (e.g., ollama, gemini)
   --use-provider    Override provider for all agents (e.g., ollama, gemini)
   --use-model       Override model name for all agents
 
+Embeddings:
+  --embed-provider  Override the embedding backend for Smart Context (ollama, openai, mistral, gemini, cohere, local-onnx)
+  --embed-model     Override the embedding model for Smart Context
+
 Logging:
   --log             Enable file-based execution logging
+  --log-format      Log file format: "text" (default) or "ndjson"
+  --log-serve       Serve live execution events as SSE and past runs for replay on this address (e.g. :8080)
+
+Metrics:
+  --metrics-addr    Serve Prometheus metrics on this address (e.g. :9090)
+  --metrics-token   Require this token as the HTTP Basic Auth password for /metrics
+
+Checkpointing:
+  --checkpoint      Checkpoint progress so the run can be resumed with 'orka runs resume'
+  --resume-from     With --continue --checkpoint, force re-execution from this agent ID onward
+  --replay          With --continue --checkpoint, re-run using only cached outputs (no LLM calls)
 
 Examples:
   orka run workflow.yaml
   orka run workflow.yaml --smart-context
   orka run workflow.yaml --use-provider ollama --use-model llama3
-  orka run workflow.yaml --continue --prompt "Follow up question"`,
+  orka run workflow.yaml --continue --prompt "Follow up question"
+  orka run workflow.yaml --continue --checkpoint --resume-from writer
+  orka run workflow.yaml --continue --checkpoint --replay`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		workflowFile := args[0]
@@ -90,6 +131,23 @@ Examples:
 			}
 		}
 
+		// Launch any gRPC backends declared in ~/.orka.yaml's backends: list
+		// before touching agents, so a "provider: grpc" model can dial them
+		// as soon as the workflow starts.
+		if backends := LoadEffectiveConfig().Backends; len(backends) > 0 {
+			fmt.Printf("🔌 Starting %d gRPC backend(s)...\n", len(backends))
+			procs, err := agent.SpawnBackends(backends)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error starting backends: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() {
+				for _, p := range procs {
+					p.Stop()
+				}
+			}()
+		}
+
 		// Check and prompt for missing API keys
 		if err := ensureAPIKeys(config); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -118,17 +176,69 @@ Examples:
 			fmt.Printf("ğŸ“ New session: %s\n", session.ID)
 		}
 
+		// Handle --edit / --branch
+		if editMsgID != "" {
+			msg, ok := session.Message(editMsgID)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: message %s not found in session %s\n", editMsgID, session.ID)
+				os.Exit(1)
+			}
+
+			edited, err := editInEditor(msg.Content)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error editing message: %v\n", err)
+				os.Exit(1)
+			}
+
+			branchID, err := session.ForkFrom(msg.ParentID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error forking session: %v\n", err)
+				os.Exit(1)
+			}
+			if branchName != "" {
+				session.RenameBranch(branchID, branchName)
+			}
+			session.AddMessage(msg.AgentID, msg.Role, edited)
+			fmt.Printf("Forked branch %s from message %s\n", branchID, editMsgID)
+		} else if branchName != "" {
+			branch, ok := session.BranchByName(branchName)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: branch %q not found in session %s\n", branchName, session.ID)
+				os.Exit(1)
+			}
+			if err := session.Checkout(branch.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking out branch: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Checked out branch: %s\n", branchName)
+		}
+
+		// --prompt-editor composes the prompt in $EDITOR instead of --prompt
+		if promptEditor && userPrompt == "" {
+			edited, err := editInEditor(promptEditorTemplate(session))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error composing prompt: %v\n", err)
+				os.Exit(1)
+			}
+			userPrompt = stripCommentLines(edited)
+		}
+
 		// If user provided a prompt, add it to session
 		if userPrompt != "" {
 			session.AddMessage("user", "input", userPrompt)
 			fmt.Printf("ğŸ’¬ User prompt: %s\n", userPrompt)
+		} else if promptEditor {
+			fmt.Println("Prompt was empty; discarding.")
 		}
 
 		// Handle Smart Context (Vector Search)
 		if smartContext {
-			fmt.Println("ğŸ§  Smart Context: Searching past sessions...")
-			// TODO: Make embedding model configurable
-			store, err := vectorstore.NewChromemStoreWithOllama("nomic-embed-text")
+			fmt.Println("🧠 Smart Context: Searching past sessions...")
+			embedOpts := resolveEmbeddingOptions(config)
+			if err := vectorstore.CheckEmbedderLock(embedOpts.Path, vectorstore.EmbedderIdentity(embedOpts)); err != nil {
+				fmt.Printf("   Warning: %v\n", err)
+			}
+			store, err := vectorstore.Open("chromem://", embedOpts)
 			if err == nil {
 				defer store.Close()
 				query := userPrompt
@@ -152,25 +262,60 @@ Examples:
 			}
 		}
 
-		// Initialize logger if enabled
-		var logger *logging.Logger
+		// Initialize the file-based log sink if enabled
+		var fileLogger *logging.Logger
 		if enableLogging {
 			var err error
-			logger, err = logging.NewLogger(session.ID, "")
+			if logFormat == "ndjson" {
+				fileLogger, err = logging.NewNDJSONLogger(session.ID, "")
+			} else {
+				fileLogger, err = logging.NewLogger(session.ID, "")
+			}
 			if err != nil {
-				fmt.Printf("âš ï¸  Failed to create logger: %v\n", err)
+				fmt.Printf("⚠️  Failed to create logger: %v\n", err)
+				fileLogger = nil
 			} else {
-				fmt.Printf("ğŸ“ Logging execution to: %s\n", logger.GetFilePath())
-				defer logger.Close()
+				fmt.Printf("📁 Logging execution to: %s\n", fileLogger.GetFilePath())
+				defer fileLogger.Close()
 			}
-		} else {
-			// Use null logger if disabled
-			logger = &logging.Logger{} // Will be handled as disabled
 		}
 
-		executor := engine.NewExecutor(config)
-		if enableLogging && logger != nil {
-			executor.SetLogger(logger)
+		// Start the live event/replay sink if requested
+		var httpSink *logging.HTTPHandler
+		if logServeAddr != "" {
+			httpSink = logging.NewHTTPHandler("")
+			go func() {
+				if err := http.ListenAndServe(logServeAddr, httpSink.Handler()); err != nil {
+					fmt.Fprintf(os.Stderr, "log server error: %v\n", err)
+				}
+			}()
+			fmt.Printf("📡 Log server: http://%s/events\n", logServeAddr)
+		}
+
+		var executorOpts []engine.Option
+		if enableCheckpoint {
+			store := checkpoint.NewFSStore("")
+			executorOpts = append(executorOpts, engine.WithCheckpointStore(store), engine.WithRunID(session.ID))
+		}
+		// Start Prometheus metrics endpoint if requested
+		if metricsAddr != "" {
+			m := metrics.New()
+			executorOpts = append(executorOpts, engine.WithMetrics(m))
+
+			go func() {
+				if err := http.ListenAndServe(metricsAddr, m.Handler(metricsToken)); err != nil {
+					fmt.Fprintf(os.Stderr, "metrics server error: %v\n", err)
+				}
+			}()
+			fmt.Printf("ğŸ“Š Metrics: http://%s/metrics\n", metricsAddr)
+		}
+
+		executor := engine.NewExecutor(config, executorOpts...)
+		if fileLogger != nil {
+			executor.AddSink(fileLogger.Handler)
+		}
+		if httpSink != nil {
+			executor.AddSink(httpSink)
 		}
 
 		// Pass session history (including user prompt) to executor
@@ -279,7 +424,44 @@ Examples:
 			fmt.Println()
 		}
 
-		output, err := executor.Execute()
+		// With --checkpoint and a prior checkpoint for this session, resume
+		// from it instead of re-running every step - --resume-from forces a
+		// specific agent (and anything after it) to redo regardless of its
+		// recorded status, and --replay requires a checkpoint to already
+		// exist so the run is a deterministic replay of its cached outputs.
+		runExecutor := executor.Execute
+		if enableCheckpoint && (sessionID != "" || continueLatest) {
+			if _, loadErr := checkpoint.NewFSStore("").Load(session.ID); loadErr == nil {
+				if resumeFromStep != "" {
+					runExecutor = func() (string, error) { return executor.ResumeFrom(session.ID, resumeFromStep) }
+				} else if replayOnly {
+					runExecutor = func() (string, error) { return executor.Replay(session.ID) }
+				} else {
+					runExecutor = func() (string, error) { return executor.Resume(session.ID) }
+				}
+			} else if resumeFromStep != "" || replayOnly {
+				fmt.Fprintf(os.Stderr, "Error: --resume-from/--replay requires an existing checkpoint for session %s: %v\n", session.ID, loadErr)
+				os.Exit(1)
+			}
+		}
+
+		var output string
+		if tuiMode && isTerminal() {
+			events := make(chan engine.Event, 64)
+			executor.SetEventSink(events)
+
+			done := make(chan struct{})
+			go func() {
+				output, err = runExecutor()
+				close(done)
+			}()
+			if tuiErr := tui.Run(executor, config, events); tuiErr != nil {
+				fmt.Fprintf(os.Stderr, "TUI error: %v\n", tuiErr)
+			}
+			<-done
+		} else {
+			output, err = runExecutor()
+		}
 		if err != nil {
 			// Save partial session progress before exiting
 			if saveErr := session.Save(); saveErr != nil {
@@ -322,10 +504,16 @@ Examples:
 
 		// Index session in vector store
 		go func() {
-			store, err := vectorstore.NewChromemStoreWithOllama("nomic-embed-text")
+			embedOpts := resolveEmbeddingOptions(config)
+			embedderID := vectorstore.EmbedderIdentity(embedOpts)
+			if err := vectorstore.CheckEmbedderLock(embedOpts.Path, embedderID); err != nil {
+				fmt.Printf("\nWarning: %v\n", err)
+				return
+			}
+			store, err := vectorstore.Open("chromem://", embedOpts)
 			if err == nil {
 				fmt.Print("ğŸ§  Indexing session...")
-				if err := vectorstore.IndexSession(store, session); err != nil {
+				if err := vectorstore.IndexSession(store, session, embedderID); err != nil {
 					fmt.Printf(" failed: %v\n", err)
 				} else {
 					fmt.Println(" done.")
@@ -347,7 +535,13 @@ Examples:
 
 		// Stats summary
 		elapsed := executor.Stats.GetElapsedTime()
-		cost := executor.Stats.EstimateCost()
+		pricingCatalog, err := pricing.LoadEffective(pricingFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			pricingCatalog, _ = pricing.DefaultCatalog()
+		}
+		costReport := executor.Stats.EstimateCost(pricingCatalog)
+		cost := costReport.TotalCost
 
 		fmt.Println(ColorGreen + "â•”â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•—" + ColorReset)
 		fmt.Printf(ColorGreen+"â•‘"+ColorReset+"  ğŸ’¾ Session: "+ColorBold+"%-64s"+ColorReset+ColorGreen+" â•‘"+ColorReset+"\n", session.ID)
@@ -368,14 +562,28 @@ func init() {
 	runCmd.Flags().StringVar(&useProvider, "use-provider", "", "Override provider for all agents (e.g., ollama, gemini)")
 	runCmd.Flags().StringVar(&useModel, "use-model", "", "Override model for all agents (e.g., llama3, gemini-2.5-flash)")
 	runCmd.Flags().BoolVar(&enableLogging, "log", false, "Enable file-based execution logging")
+	runCmd.Flags().StringVar(&logFormat, "log-format", "text", "Log file format when --log is set: \"text\" or \"ndjson\"")
+	runCmd.Flags().StringVar(&logServeAddr, "log-serve", "", "Serve live execution events as SSE and past runs for replay on this address (e.g. :8080)")
+	runCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. :9090)")
+	runCmd.Flags().StringVar(&metricsToken, "metrics-token", "", "Require this token as the HTTP Basic Auth password for /metrics")
+	runCmd.Flags().BoolVar(&enableCheckpoint, "checkpoint", false, "Checkpoint progress so the run can be resumed with 'orka runs resume'")
+	runCmd.Flags().StringVar(&resumeFromStep, "resume-from", "", "With --continue --checkpoint, force re-execution from this agent ID onward")
+	runCmd.Flags().BoolVar(&replayOnly, "replay", false, "With --continue --checkpoint, re-run using only cached outputs (no LLM calls)")
+	runCmd.Flags().StringVar(&pricingFile, "pricing-file", "", "Pricing catalog file to use for cost estimation (default: ~/.orka/pricing.yaml or the built-in catalog)")
+	runCmd.Flags().StringVar(&editMsgID, "edit", "", "Edit message <id> from --session in $EDITOR, then fork a new branch from it")
+	runCmd.Flags().StringVar(&branchName, "branch", "", "With --edit, name the forked branch; alone, check out an existing branch by name")
+	runCmd.Flags().BoolVar(&tuiMode, "tui", false, "Launch an interactive TUI with live per-agent panes instead of plain output (falls back to plain output when stdout isn't a terminal)")
+	runCmd.Flags().BoolVar(&promptEditor, "prompt-editor", false, "Compose the prompt in $EDITOR instead of passing --prompt")
+	runCmd.Flags().StringVar(&embedProvider, "embed-provider", "", "Override the embedding backend for Smart Context (default: from workflow's embeddings: block, or ollama)")
+	runCmd.Flags().StringVar(&embedModel, "embed-model", "", "Override the embedding model for Smart Context")
 }
 
 func ensureAPIKeys(config *types.WorkflowConfig) error {
 	cliConfig := LoadEffectiveConfig()
 
 	for name, model := range config.Models {
-		// Ollama doesn't need API key
-		if model.Provider == "ollama" {
+		// Ollama and grpc backends don't need an API key
+		if model.Provider == "ollama" || model.Provider == "grpc" {
 			continue
 		}
 
@@ -446,6 +654,31 @@ func getEnvKeyName(provider string) string {
 	}
 }
 
+// resolveEmbeddingOptions builds vectorstore.Options for Smart Context and
+// session indexing. Precedence, lowest to highest: `orka config
+// --embedding-provider/--embedding-model` (used only for a field the
+// workflow's `embeddings:` block leaves empty), the `embeddings:` block, then
+// --embed-provider/--embed-model on this command.
+func resolveEmbeddingOptions(config *types.WorkflowConfig) vectorstore.Options {
+	opts := vectorstore.OptionsFromConfig(config.Embeddings, "")
+
+	cliConfig := LoadEffectiveConfig()
+	if opts.EmbeddingProvider == "" && cliConfig.EmbeddingProvider != "" {
+		opts.EmbeddingProvider = cliConfig.EmbeddingProvider
+	}
+	if opts.EmbeddingModel == "" && cliConfig.EmbeddingModel != "" {
+		opts.EmbeddingModel = cliConfig.EmbeddingModel
+	}
+
+	if embedProvider != "" {
+		opts.EmbeddingProvider = embedProvider
+	}
+	if embedModel != "" {
+		opts.EmbeddingModel = embedModel
+	}
+	return opts
+}
+
 // getAgentByID finds an agent by ID from the agents list
 func getAgentByID(agents []types.Agent, id string) *types.Agent {
 	for i := range agents {
@@ -455,3 +688,44 @@ func getAgentByID(agents []types.Agent, id string) *types.Agent {
 	}
 	return nil
 }
+
+// promptEditorTemplate builds the seed content --prompt-editor opens in
+// $EDITOR: a blank line to type into, followed by a comment block (git
+// commit message style - stripped by stripCommentLines before use) showing
+// the active branch's last few messages for context when continuing a
+// session, or just instructions for a blank one.
+func promptEditorTemplate(session *memory.Session) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString("# Write your prompt above. Lines starting with '#' are ignored.\n")
+	b.WriteString("# An empty or unchanged file discards the prompt.\n")
+
+	history := session.BranchHistory(session.ActiveBranch)
+	if len(history) > 0 {
+		tail := history
+		if len(tail) > 3 {
+			tail = tail[len(tail)-3:]
+		}
+		b.WriteString("#\n# Recent session context:\n")
+		for _, msg := range tail {
+			for _, line := range splitLines(fmt.Sprintf("[%s] %s: %s", msg.AgentID, msg.Role, msg.Content)) {
+				b.WriteString("# " + line + "\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// stripCommentLines removes '#'-prefixed lines and trims the result, the
+// same convention promptEditorTemplate seeds into $EDITOR.
+func stripCommentLines(s string) string {
+	var kept []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"Orkflow/internal/checkpoint"
+	"Orkflow/internal/engine"
+	"Orkflow/internal/parser"
+
+	"github.com/spf13/cobra"
+)
+
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Manage checkpointed workflow runs",
+	Long:  `List, inspect, and resume workflow runs checkpointed with "orka run --checkpoint".`,
+}
+
+var runsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all checkpointed runs",
+	Run: func(cmd *cobra.Command, args []string) {
+		checkpoints, err := checkpoint.NewFSStore("").List()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing runs: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(checkpoints) == 0 {
+			fmt.Println("No checkpointed runs found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RUN ID\tSTATUS\tSTEP\tLAST UPDATED")
+		fmt.Fprintln(w, "------\t------\t----\t------------")
+
+		for _, cp := range checkpoints {
+			ago := time.Since(cp.UpdatedAt).Round(time.Second)
+			fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s ago\n", cp.RunID, cp.Status, cp.CurrentStep, cp.TotalSteps, ago)
+		}
+		w.Flush()
+	},
+}
+
+var runsShowCmd = &cobra.Command{
+	Use:   "show <run-id>",
+	Short: "Show the checkpointed state of a run",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cp, err := checkpoint.NewFSStore("").Load(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading run: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Run:    %s\n", cp.RunID)
+		fmt.Printf("Status: %s\n", cp.Status)
+		fmt.Printf("Step:   %d/%d\n", cp.CurrentStep, cp.TotalSteps)
+		fmt.Printf("Updated: %s\n", cp.UpdatedAt.Format(time.RFC3339))
+		if cp.Error != "" {
+			fmt.Printf("Error:  %s\n", cp.Error)
+		}
+
+		fmt.Println("\nAgents:")
+		for agentID, status := range cp.AgentStatus {
+			fmt.Printf("  %-20s %s\n", agentID, status)
+		}
+	},
+}
+
+var runsResumeCmd = &cobra.Command{
+	Use:   "resume <run-id> <workflow.yaml>",
+	Short: "Resume a checkpointed run, re-enqueueing any lost agents",
+	Long: `Resume reloads a checkpointed run, marks any agent still "running" at
+the time of the crash as lost, and re-executes the workflow — agents that
+already completed are skipped so their LLM calls aren't repeated.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runID := args[0]
+		workflowFile := args[1]
+
+		config, err := parser.ParseYAML(workflowFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing workflow: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := ensureAPIKeys(config); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		store := checkpoint.NewFSStore("")
+		executor := engine.NewExecutor(config, engine.WithCheckpointStore(store))
+
+		output, err := executor.Resume(runID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resuming run: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println(output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runsCmd)
+	runsCmd.AddCommand(runsListCmd)
+	runsCmd.AddCommand(runsShowCmd)
+	runsCmd.AddCommand(runsResumeCmd)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"Orkflow/internal/parser"
+	"Orkflow/internal/server"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr  string
+	serveToken string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve <workflow.yaml>",
+	Short: "Serve a workflow as an OpenAI-compatible HTTP API",
+	Long: `Serve boots an HTTP server exposing a workflow as an OpenAI-compatible
+API, so any OpenAI SDK or chat UI (LibreChat, Chatbot-UI, etc.) can use orka
+as a drop-in backend:
+
+  POST /v1/chat/completions   model -> an agent id (run that agent directly)
+                               or anything else (run the whole workflow)
+  POST /v1/completions        legacy prompt-based completion
+  POST /v1/embeddings         embed text with the workflow's embeddings: backend
+  GET  /v1/models             list agent ids and "workflow" as available models
+
+Both completion endpoints support "stream": true, returned as
+Server-Sent Events the same way the OpenAI API streams them.
+
+Without --serve-token, anyone who can reach --addr can trigger workflow
+and agent runs - burning API keys and invoking tools - so production use
+should always set one.
+
+Examples:
+  orka serve workflow.yaml
+  orka serve workflow.yaml --addr :11434
+  orka serve workflow.yaml --serve-token secret`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workflowFile := args[0]
+
+		config, err := parser.ParseYAML(workflowFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing workflow: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := ensureAPIKeys(config); err != nil {
+			fmt.Fprintf(os.Stderr, "Error configuring API keys: %v\n", err)
+			os.Exit(1)
+		}
+
+		srv := server.New(config)
+		fmt.Printf("Serving %q on %s (/v1/chat/completions, /v1/completions, /v1/embeddings, /v1/models)\n", workflowFile, serveAddr)
+		if serveToken == "" {
+			fmt.Fprintln(os.Stderr, "Warning: --serve-token is not set; anyone who can reach this address can run workflows and agents.")
+		}
+		if err := http.ListenAndServe(serveAddr, srv.Handler(serveToken)); err != nil {
+			fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to serve the OpenAI-compatible API on")
+	serveCmd.Flags().StringVar(&serveToken, "serve-token", "", "Require this token as the HTTP Basic Auth password for every request")
+}
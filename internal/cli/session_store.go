@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Orkflow Authors
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"Orkflow/internal/memory"
+)
+
+// sessionEncryptionKeyEnv names the environment variable Encrypted reads its
+// key material from when set.
+const sessionEncryptionKeyEnv = "ORKA_SESSION_KEY"
+
+// initSessionStore resolves the session storage backend from --session-store
+// (falling back to ~/.orka.yaml's session_store, then the file-backed
+// default), wraps it in encryption if ORKA_SESSION_KEY is set, and installs
+// it as the package-level memory store used by every command.
+func initSessionStore() {
+	backend := sessionStoreFlag
+	if backend == "" {
+		backend = LoadEffectiveConfig().SessionStore
+	}
+
+	store, err := openSessionStore(backend)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if os.Getenv(sessionEncryptionKeyEnv) != "" {
+		encrypted, err := memory.NewEncryptedFromEnv(store, sessionEncryptionKeyEnv)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		store = encrypted
+	}
+
+	memory.SetStore(store)
+}
+
+func openSessionStore(backend string) (memory.SessionStore, error) {
+	switch {
+	case backend == "" || backend == "file":
+		return memory.NewFileStore(""), nil
+	case backend == "bolt":
+		return memory.NewBoltStore("")
+	case strings.HasPrefix(backend, "redis://"):
+		return memory.NewRemoteStore(strings.TrimPrefix(backend, "redis://"))
+	default:
+		return nil, fmt.Errorf("unknown session store %q (expected file, bolt, or redis://host:port)", backend)
+	}
+}
@@ -239,6 +239,62 @@ var sessionsDeleteCmd = &cobra.Command{
 	},
 }
 
+var editMessageID string
+
+var sessionsEditCmd = &cobra.Command{
+	Use:   "edit <session-id>",
+	Short: "Edit a past message in $EDITOR and fork a new branch from it",
+	Long: `Opens the message named by --message in $EDITOR. On save, forks a new
+branch from that message's parent and appends the edited content as a new
+message on the forked branch, leaving the original branch untouched. Ties
+into 'orka branches' and 'orka run --branch' for listing and resuming it.
+
+Examples:
+  orka sessions edit abc123 --message m5`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if editMessageID == "" {
+			fmt.Fprintln(os.Stderr, "Error: --message is required")
+			os.Exit(1)
+		}
+
+		session, err := memory.LoadSession(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading session: %v\n", err)
+			os.Exit(1)
+		}
+
+		msg, ok := session.Message(editMessageID)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: message %s not found in session %s\n", editMessageID, session.ID)
+			os.Exit(1)
+		}
+
+		edited, err := editInEditor(msg.Content)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error editing message: %v\n", err)
+			os.Exit(1)
+		}
+		if edited == msg.Content {
+			fmt.Println("No changes made; nothing to fork.")
+			return
+		}
+
+		branchID, err := session.ForkFrom(msg.ParentID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error forking session: %v\n", err)
+			os.Exit(1)
+		}
+		session.AddMessage(msg.AgentID, msg.Role, edited)
+
+		if err := session.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving session: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Forked branch %s from message %s\n", branchID, editMessageID)
+	},
+}
+
 var sessionsCleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Remove expired and excess sessions",
@@ -257,7 +313,9 @@ func init() {
 	sessionsCmd.AddCommand(sessionsShowCmd)
 	sessionsCmd.AddCommand(sessionsDeleteCmd)
 	sessionsCmd.AddCommand(sessionsCleanCmd)
+	sessionsCmd.AddCommand(sessionsEditCmd)
 
 	sessionsShowCmd.Flags().BoolVarP(&showFull, "full", "f", false, "Show complete message content")
 	sessionsShowCmd.Flags().BoolVarP(&showWorkflowOnly, "workflow", "w", false, "Show only the workflow diagram")
+	sessionsEditCmd.Flags().StringVar(&editMessageID, "message", "", "ID of the message to edit")
 }
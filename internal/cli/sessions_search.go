@@ -6,30 +6,56 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"Orkflow/internal/vectorstore"
 
 	"github.com/spf13/cobra"
 )
 
-var searchLimit int
+var (
+	searchK             int
+	searchWorkflow      string
+	searchRole          string
+	searchSince         string
+	searchEmbedProvider string
+	searchEmbedModel    string
+)
 
 var sessionsSearchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search sessions semantically",
-	Long: `Search through past session messages using semantic similarity.
+	Long: `Search through past session messages using semantic similarity, with
+optional filters and a BM25/dense hybrid fallback when the backing store
+supports it.
 
 Requires Ollama running locally with an embedding model (e.g., nomic-embed-text).
 
 Examples:
   orka sessions search "API design patterns"
-  orka sessions search "database optimization" --limit 5`,
+  orka sessions search "database optimization" --k 5
+  orka sessions search "bug fix" --workflow deploy.yaml --role reviewer --since 24h`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
 
-		// Create vector store with Ollama embeddings
-		store, err := vectorstore.NewChromemStoreWithOllama("nomic-embed-text")
+		var since time.Time
+		if searchSince != "" {
+			d, err := time.ParseDuration(searchSince)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --since duration %q: %v\n", searchSince, err)
+				os.Exit(1)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		// Create vector store with the configured (default: Ollama) embeddings
+		embedOpts := vectorstore.Options{EmbeddingProvider: searchEmbedProvider, EmbeddingModel: searchEmbedModel}
+		if err := vectorstore.CheckEmbedderLock(embedOpts.Path, vectorstore.EmbedderIdentity(embedOpts)); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+		store, err := vectorstore.Open("chromem://", embedOpts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: Could not connect to vector store: %v\n", err)
 			fmt.Println("\n💡 Make sure Ollama is running with an embedding model:")
@@ -40,12 +66,54 @@ Examples:
 
 		fmt.Printf("🔍 Searching for: \"%s\"\n\n", query)
 
-		results, err := store.Search(query, searchLimit)
+		where := map[string]string{}
+		if searchWorkflow != "" {
+			where["workflow"] = searchWorkflow
+		}
+		if searchRole != "" {
+			where["role"] = searchRole
+		}
+		var whereArg map[string]string
+		if len(where) > 0 {
+			whereArg = where
+		}
+
+		var results []vectorstore.SearchResult
+		if hybrid, ok := store.(vectorstore.HybridSearcher); ok {
+			// Hybrid mode does not apply Where, so a search with
+			// --workflow/--role filters runs in Dense mode, which does.
+			mode := vectorstore.Hybrid
+			if whereArg != nil {
+				mode = vectorstore.Dense
+			}
+			results, err = hybrid.SearchWithOptions(query, vectorstore.SearchOptions{
+				Limit: searchK,
+				Where: whereArg,
+				Mode:  mode,
+			})
+		} else {
+			if whereArg != nil {
+				fmt.Println(ColorText("Warning: this store does not support metadata filters; --workflow/--role ignored.", ColorYellow))
+			}
+			results, err = store.Search(query, searchK)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error searching: %v\n", err)
 			os.Exit(1)
 		}
 
+		if !since.IsZero() {
+			filtered := results[:0]
+			for _, r := range results {
+				ts, err := time.Parse("2006-01-02 15:04:05", r.Metadata["timestamp"])
+				if err == nil && ts.Before(since) {
+					continue
+				}
+				filtered = append(filtered, r)
+			}
+			results = filtered
+		}
+
 		if len(results) == 0 {
 			fmt.Println("No matching sessions found.")
 			fmt.Println("Run some workflows first to build up session history.")
@@ -55,23 +123,97 @@ Examples:
 		for i, r := range results {
 			fmt.Printf("─── Result %d (%.1f%% match) ───\n", i+1, r.Score*100)
 			if sessionID, ok := r.Metadata["session_id"]; ok {
-				fmt.Printf("Session: %s\n", sessionID)
+				fmt.Printf("Session:   %s\n", sessionID)
+			}
+			if workflow, ok := r.Metadata["workflow"]; ok && workflow != "" {
+				fmt.Printf("Workflow:  %s\n", workflow)
 			}
 			if agentID, ok := r.Metadata["agent_id"]; ok {
-				fmt.Printf("Agent: %s\n", agentID)
+				fmt.Printf("Agent:     %s\n", agentID)
 			}
-
-			// Show truncated content
-			content := r.Content
-			if len(content) > 300 {
-				content = content[:300] + "..."
+			if role, ok := r.Metadata["role"]; ok {
+				fmt.Printf("Role:      %s\n", role)
 			}
-			fmt.Printf("\n%s\n\n", content)
+			if ts, ok := r.Metadata["timestamp"]; ok {
+				fmt.Printf("Timestamp: %s\n", ts)
+			}
+
+			fmt.Printf("\n%s\n\n", highlightSnippet(r.Content, query, 300))
 		}
 	},
 }
 
+// highlightSnippet trims content to a window of width characters centered on
+// the earliest query term match, then colors every occurrence of a query
+// term inside that window so it stands out in the result listing.
+func highlightSnippet(content, query string, width int) string {
+	terms := strings.Fields(strings.ToLower(query))
+	lower := strings.ToLower(content)
+
+	pos := -1
+	for _, t := range terms {
+		if idx := strings.Index(lower, t); idx != -1 && (pos == -1 || idx < pos) {
+			pos = idx
+		}
+	}
+	if pos == -1 {
+		pos = 0
+	}
+
+	start := pos - width/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > len(content) {
+		end = len(content)
+	}
+	snippet := content[start:end]
+
+	for _, t := range terms {
+		snippet = highlightTerm(snippet, t)
+	}
+
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(content) {
+		snippet = snippet + "..."
+	}
+	return snippet
+}
+
+// highlightTerm wraps every case-insensitive occurrence of term in snippet
+// with the bold-yellow color pair, leaving the rest of the text untouched.
+func highlightTerm(snippet, term string) string {
+	if term == "" {
+		return snippet
+	}
+	lower := strings.ToLower(snippet)
+
+	var out strings.Builder
+	i := 0
+	for {
+		idx := strings.Index(lower[i:], term)
+		if idx == -1 {
+			out.WriteString(snippet[i:])
+			break
+		}
+		matchStart := i + idx
+		matchEnd := matchStart + len(term)
+		out.WriteString(snippet[i:matchStart])
+		out.WriteString(ColorText(snippet[matchStart:matchEnd], ColorBold+ColorYellow))
+		i = matchEnd
+	}
+	return out.String()
+}
+
 func init() {
 	sessionsCmd.AddCommand(sessionsSearchCmd)
-	sessionsSearchCmd.Flags().IntVarP(&searchLimit, "limit", "l", 3, "Number of results to return")
+	sessionsSearchCmd.Flags().IntVarP(&searchK, "k", "k", 3, "Number of results to return")
+	sessionsSearchCmd.Flags().StringVar(&searchWorkflow, "workflow", "", "Filter to sessions from this workflow file")
+	sessionsSearchCmd.Flags().StringVar(&searchRole, "role", "", "Filter to messages with this role (e.g. user, assistant)")
+	sessionsSearchCmd.Flags().StringVar(&searchSince, "since", "", "Only show messages newer than this duration ago (e.g. 24h)")
+	sessionsSearchCmd.Flags().StringVar(&searchEmbedProvider, "embed-provider", "ollama", "Embedding backend to search with (ollama, openai, mistral, gemini, cohere, local-onnx)")
+	sessionsSearchCmd.Flags().StringVar(&searchEmbedModel, "embed-model", "nomic-embed-text", "Embedding model to search with")
 }
@@ -0,0 +1,63 @@
+package tui
+
+import "Orkflow/pkg/types"
+
+// StepStatus is where a graph node currently sits in its lifecycle.
+type StepStatus int
+
+const (
+	StepPending StepStatus = iota
+	StepRunning
+	StepDone
+	StepFailed
+)
+
+// Step is one node in the workflow graph shown in the sidebar.
+type Step struct {
+	AgentID string
+	Role    string
+	Status  StepStatus
+}
+
+// BuildGraph flattens config's workflow (sequential steps, parallel
+// branches, or a single supervisor root) into the ordered node list the
+// sidebar renders, mirroring the same sequential/parallel shape runCmd's
+// banner already draws.
+func BuildGraph(config *types.WorkflowConfig) []Step {
+	roleByID := make(map[string]string, len(config.Agents))
+	for _, a := range config.Agents {
+		roleByID[a.ID] = a.Role
+	}
+
+	newStep := func(agentID string) Step {
+		return Step{AgentID: agentID, Role: roleByID[agentID]}
+	}
+
+	if config.Workflow == nil {
+		for _, a := range config.Agents {
+			if a.IsSupervisor() {
+				return []Step{newStep(a.ID)}
+			}
+		}
+		if len(config.Agents) > 0 {
+			return []Step{newStep(config.Agents[0].ID)}
+		}
+		return nil
+	}
+
+	var steps []Step
+	switch config.Workflow.Type {
+	case "sequential":
+		for _, s := range config.Workflow.Steps {
+			steps = append(steps, newStep(s.Agent))
+		}
+	case "parallel":
+		for _, branchID := range config.Workflow.Branches {
+			steps = append(steps, newStep(branchID))
+		}
+		if config.Workflow.Then != nil {
+			steps = append(steps, newStep(config.Workflow.Then.Agent))
+		}
+	}
+	return steps
+}
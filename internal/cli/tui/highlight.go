@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/quick"
+)
+
+// codeFenceRe matches a ```lang\n...\n``` fenced code block, capturing the
+// (optional) language tag and the code body.
+var codeFenceRe = regexp.MustCompile("(?s)```(\\w*)\\n(.*?)```")
+
+// highlightCodeBlocks returns content with every fenced code block replaced
+// by its chroma-rendered (terminal256, monokai) syntax highlighting. Text
+// outside code fences, and any block chroma fails to highlight, passes
+// through unchanged.
+func highlightCodeBlocks(content string) string {
+	return codeFenceRe.ReplaceAllStringFunc(content, func(block string) string {
+		m := codeFenceRe.FindStringSubmatch(block)
+		lang, code := m[1], m[2]
+		if lang == "" {
+			lang = "text"
+		}
+
+		var buf strings.Builder
+		if err := quick.Highlight(&buf, code, lang, "terminal256", "monokai"); err != nil {
+			return block
+		}
+		return buf.String()
+	})
+}
@@ -0,0 +1,304 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"Orkflow/internal/engine"
+	"Orkflow/internal/pricing"
+	"Orkflow/pkg/types"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const sidebarWidth = 24
+
+var (
+	styleSidebar = lipgloss.NewStyle().Width(sidebarWidth).Padding(0, 1)
+	stylePending = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	styleRunning = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	styleDone    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	styleFailed  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	styleFocused = lipgloss.NewStyle().Bold(true).Underline(true)
+	styleFooter  = lipgloss.NewStyle().Faint(true)
+)
+
+// eventMsg wraps an engine.Event as a bubbletea message.
+type eventMsg engine.Event
+
+// tickMsg drives the periodic footer-stats refresh.
+type tickMsg time.Time
+
+// Model is the bubbletea model behind `orka run --tui`: a sidebar showing
+// the workflow graph's live status, a scrollback pane per agent, and a
+// footer with running stats. It is fed by the Events channel Executor's
+// SetEventSink was given - Execute must run concurrently in its own
+// goroutine for the program to receive anything.
+type Model struct {
+	executor *engine.Executor
+	events   chan engine.Event
+
+	steps   []Step
+	stepIdx map[string]int
+
+	panes      []string
+	paneIdx    map[string]int
+	focused    int
+	transcript map[string]string
+
+	viewport viewport.Model
+	ready    bool
+	width    int
+	height   int
+
+	catalog *pricing.Catalog
+	start   time.Time
+	status  string
+	errMsg  string
+}
+
+// NewModel builds the initial Model for config's workflow graph. events must
+// be the same channel passed to Executor.SetEventSink.
+func NewModel(executor *engine.Executor, config *types.WorkflowConfig, events chan engine.Event) Model {
+	steps := BuildGraph(config)
+	stepIdx := make(map[string]int, len(steps))
+	for i, s := range steps {
+		stepIdx[s.AgentID] = i
+	}
+
+	catalog, _ := pricing.DefaultCatalog()
+
+	return Model{
+		executor:   executor,
+		events:     events,
+		steps:      steps,
+		stepIdx:    stepIdx,
+		paneIdx:    make(map[string]int),
+		transcript: make(map[string]string),
+		catalog:    catalog,
+		start:      time.Now(),
+		status:     "running",
+	}
+}
+
+func listenForEvents(events chan engine.Event) tea.Cmd {
+	return func() tea.Msg {
+		evt, ok := <-events
+		if !ok {
+			return nil
+		}
+		return eventMsg(evt)
+	}
+}
+
+func tickEvery() tea.Cmd {
+	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(listenForEvents(m.events), tickEvery())
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		vpWidth, vpHeight := m.width-sidebarWidth-2, m.height-6
+		if !m.ready {
+			m.viewport = viewport.New(vpWidth, vpHeight)
+			m.ready = true
+		} else {
+			m.viewport.Width, m.viewport.Height = vpWidth, vpHeight
+		}
+		m.viewport.SetContent(m.paneContent())
+		return m, nil
+
+	case tickMsg:
+		return m, tickEvery()
+
+	case eventMsg:
+		m.applyEvent(engine.Event(msg))
+		if m.ready {
+			m.viewport.SetContent(m.paneContent())
+		}
+		return m, listenForEvents(m.events)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "tab":
+			m.cyclePane(1)
+		case "shift+tab":
+			m.cyclePane(-1)
+		case "p":
+			m.executor.Abort()
+			m.status = "aborting"
+		case "e":
+			return m, m.openFocusedInEditor()
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		if m.ready {
+			m.viewport.SetContent(m.paneContent())
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// applyEvent folds evt into the model's sidebar status and per-agent
+// transcripts.
+func (m *Model) applyEvent(evt engine.Event) {
+	switch evt.Type {
+	case engine.EventAgentStart:
+		if i, ok := m.stepIdx[evt.AgentID]; ok {
+			m.steps[i].Status = StepRunning
+		}
+		m.addPane(evt.AgentID)
+
+	case engine.EventAgentToken:
+		m.addPane(evt.AgentID)
+		m.transcript[evt.AgentID] += evt.Content
+
+	case engine.EventToolCall:
+		m.addPane(evt.AgentID)
+		m.transcript[evt.AgentID] += fmt.Sprintf("\n[tool: %s]\n%s\n", evt.Tool, evt.Content)
+
+	case engine.EventAgentEnd:
+		if i, ok := m.stepIdx[evt.AgentID]; ok {
+			m.steps[i].Status = StepDone
+		}
+		m.addPane(evt.AgentID)
+		if m.transcript[evt.AgentID] == "" {
+			m.transcript[evt.AgentID] = evt.Content
+		}
+
+	case engine.EventWorkflowEnd:
+		m.status = "completed"
+
+	case engine.EventError:
+		m.status = "failed"
+		m.errMsg = evt.Content
+		if i, ok := m.stepIdx[evt.AgentID]; ok {
+			m.steps[i].Status = StepFailed
+		}
+	}
+}
+
+func (m *Model) addPane(agentID string) {
+	if _, ok := m.paneIdx[agentID]; ok {
+		return
+	}
+	m.paneIdx[agentID] = len(m.panes)
+	m.panes = append(m.panes, agentID)
+}
+
+func (m *Model) cyclePane(delta int) {
+	if len(m.panes) == 0 {
+		return
+	}
+	m.focused = ((m.focused+delta)%len(m.panes) + len(m.panes)) % len(m.panes)
+}
+
+func (m Model) paneContent() string {
+	if len(m.panes) == 0 {
+		return "Waiting for the first agent to start..."
+	}
+	return highlightCodeBlocks(m.transcript[m.panes[m.focused]])
+}
+
+// openFocusedInEditor writes the focused pane's full transcript to a temp
+// file and suspends the TUI to open it in $EDITOR, mirroring cli.editInEditor
+// but read-only - there's nothing to fork back into here.
+func (m Model) openFocusedInEditor() tea.Cmd {
+	if len(m.panes) == 0 {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp("", "orka-view-*.md")
+	if err != nil {
+		return nil
+	}
+	path := tmp.Name()
+	tmp.WriteString(m.transcript[m.panes[m.focused]])
+	tmp.Close()
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, path)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		os.Remove(path)
+		return nil
+	})
+}
+
+func (m Model) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+
+	sidebar := m.renderSidebar()
+	main := lipgloss.NewStyle().Width(m.viewport.Width).Render(m.viewport.View())
+	body := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, main)
+
+	return body + "\n" + m.renderFooter()
+}
+
+func (m Model) renderSidebar() string {
+	var b strings.Builder
+	b.WriteString("WORKFLOW\n\n")
+
+	for _, s := range m.steps {
+		marker, style := "o", stylePending
+		switch s.Status {
+		case StepRunning:
+			marker, style = ">", styleRunning
+		case StepDone:
+			marker, style = "x", styleDone
+		case StepFailed:
+			marker, style = "!", styleFailed
+		}
+
+		line := fmt.Sprintf("%s %s", marker, s.AgentID)
+		if idx, ok := m.paneIdx[s.AgentID]; ok && idx == m.focused {
+			line = styleFocused.Render(line)
+		} else {
+			line = style.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return styleSidebar.Render(b.String())
+}
+
+func (m Model) renderFooter() string {
+	elapsed := time.Since(m.start).Round(time.Second)
+	completed := m.executor.Stats.GetCompletedCount()
+
+	cost := "n/a"
+	if m.catalog != nil {
+		report := m.executor.Stats.EstimateCost(m.catalog)
+		cost = fmt.Sprintf("$%.4f", report.TotalCost)
+	}
+
+	stats := fmt.Sprintf("elapsed %s | completed %d/%d | est. cost %s | %s",
+		elapsed, completed, len(m.steps), cost, m.status)
+	if m.status == "failed" && m.errMsg != "" {
+		stats += " (" + m.errMsg + ")"
+	}
+	help := "tab: switch pane  up/down: scroll  p: abort  e: view in $EDITOR  q: quit"
+
+	return styleFooter.Render(stats + "\n" + help)
+}
@@ -0,0 +1,22 @@
+// Package tui implements `orka run --tui`: a bubbletea-based live view of a
+// running workflow, fed by the structured events Executor.SetEventSink
+// emits in place of (or alongside) the plain-text output runCmd prints by
+// default.
+package tui
+
+import (
+	"Orkflow/internal/engine"
+	"Orkflow/pkg/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Run launches the interactive TUI and blocks until the user quits it. The
+// caller must start executor.Execute() in its own goroutine - Run only
+// renders the events that Execute, via the channel already passed to
+// executor.SetEventSink, sends to events.
+func Run(executor *engine.Executor, config *types.WorkflowConfig, events chan engine.Event) error {
+	program := tea.NewProgram(NewModel(executor, config, events), tea.WithAltScreen())
+	_, err := program.Run()
+	return err
+}
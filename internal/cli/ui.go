@@ -1,6 +1,10 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+
+	"Orkflow/internal/pricing"
+)
 
 // ANSI color codes
 const (
@@ -61,23 +65,21 @@ func FormatDuration(seconds float64) string {
 	return fmt.Sprintf("%dm %ds", mins, secs)
 }
 
-// ModelPricing stores cost per 1M tokens (input/output)
-var ModelPricing = map[string]struct{ Input, Output float64 }{
-	"gpt-4o":           {2.50, 10.00},
-	"gpt-4o-mini":      {0.15, 0.60},
-	"gpt-4-turbo":      {10.00, 30.00},
-	"gpt-3.5-turbo":    {0.50, 1.50},
-	"gemini-2.0-flash": {0.075, 0.30},
-	"gemini-1.5-pro":   {1.25, 5.00},
-}
-
-// EstimateCost calculates cost based on token counts
+// EstimateCost calculates cost based on token counts, looking the model up
+// in the effective pricing catalog (--pricing-file / ~/.orka/pricing.yaml /
+// the embedded default). It returns 0 if the model has no catalog entry.
 func EstimateCost(model string, inputTokens, outputTokens int) float64 {
-	pricing, ok := ModelPricing[model]
+	catalog, err := pricing.LoadEffective("")
+	if err != nil {
+		return 0
+	}
+
+	entry, ok := catalog.LookupByModel(model)
 	if !ok {
 		return 0
 	}
-	inputCost := float64(inputTokens) / 1000000 * pricing.Input
-	outputCost := float64(outputTokens) / 1000000 * pricing.Output
-	return inputCost + outputCost
+
+	inputCost := float64(inputTokens) / 1000000 * entry.Input
+	outputCost := float64(outputTokens) / 1000000 * entry.Output
+	return inputCost + outputCost + entry.PerRequest
 }
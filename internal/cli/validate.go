@@ -4,33 +4,186 @@ Copyright © 2026 Orkflow Authors
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+
+	"Orkflow/internal/agent"
+	"Orkflow/internal/parser"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	validateStrict bool
+	validateFormat string
 )
 
 // validateCmd represents the validate command
 var validateCmd = &cobra.Command{
 	Use:   "validate <workflow.yaml>",
 	Short: "Validate a workflow file",
-	Long: `Validate checks a workflow YAML file for syntax errors and
-structural issues without executing it.
+	Long: `Validate checks a workflow YAML file for structural errors, dangling
+references, and dependency cycles without executing it:
+
+  - structural: every agent has an id and a model, and every tool/toolset
+    name resolves to something registered
+  - references: every tool, sub-agent, workflow step/branch/then, and
+    shared-memory "requires" key resolves to something the config actually
+    declares
+  - cycles: the requires/outputs dependency graph between agents is acyclic
 
-This is useful for checking your workflow definitions before running them.
+Problems that would stop a run (missing fields, unknown references, a
+cycle) are reported as errors and exit non-zero. Cosmetic problems (an
+agent nothing calls, a shared-memory key nobody waits on) are reported as
+warnings and exit 0, unless --strict is given.
 
 Examples:
   orka validate workflow.yaml
-  orka validate examples/sequential.yaml`,
+  orka validate examples/sequential.yaml --strict
+  orka validate workflow.yaml --format json`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		workflowFile := args[0]
-		fmt.Printf("Validating workflow: %s\n", workflowFile)
 
-		// TODO: Implement workflow validation
-		fmt.Println("Workflow validation not yet implemented")
+		config, err := parser.ParseYAML(workflowFile)
+		if err != nil {
+			reportValidateParseError(workflowFile, err)
+			os.Exit(1)
+		}
+
+		lines := agentLineNumbers(workflowFile)
+
+		runner := agent.NewRunner(config)
+		result := runner.Validate()
+
+		failed := len(result.Errors()) > 0 || (validateStrict && len(result.Warnings()) > 0)
+
+		if validateFormat == "json" {
+			printValidateJSON(workflowFile, result, lines)
+		} else {
+			printValidateText(workflowFile, result, lines)
+		}
+
+		if failed {
+			os.Exit(1)
+		}
 	},
 }
 
+func reportValidateParseError(workflowFile string, err error) {
+	if validateFormat == "json" {
+		json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+			"file":  workflowFile,
+			"valid": false,
+			"error": err.Error(),
+		})
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", workflowFile, err)
+}
+
+func printValidateText(workflowFile string, result *agent.ValidationResult, lines map[string]int) {
+	fmt.Printf("Validating workflow: %s\n\n", workflowFile)
+
+	if len(result.Issues) == 0 {
+		fmt.Println("Workflow is valid.")
+		return
+	}
+
+	for _, issue := range result.Issues {
+		if line, ok := lines[issue.AgentID]; ok && issue.AgentID != "" {
+			fmt.Printf("  %s:%d: %s\n", workflowFile, line, issue)
+		} else {
+			fmt.Printf("  %s: %s\n", workflowFile, issue)
+		}
+	}
+
+	errors, warnings := result.Errors(), result.Warnings()
+	fmt.Printf("\n%d error(s), %d warning(s)\n", len(errors), len(warnings))
+	if len(errors) == 0 && len(warnings) > 0 && validateStrict {
+		fmt.Println("(warnings treated as errors: --strict)")
+	}
+}
+
+type validateJSONIssue struct {
+	Severity string `json:"severity"`
+	AgentID  string `json:"agent_id,omitempty"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func printValidateJSON(workflowFile string, result *agent.ValidationResult, lines map[string]int) {
+	issues := make([]validateJSONIssue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		jsonIssue := validateJSONIssue{
+			Severity: string(issue.Severity),
+			AgentID:  issue.AgentID,
+			Message:  issue.Message,
+		}
+		if line, ok := lines[issue.AgentID]; ok {
+			jsonIssue.Line = line
+		}
+		issues = append(issues, jsonIssue)
+	}
+
+	out := map[string]interface{}{
+		"file":   workflowFile,
+		"valid":  len(result.Errors()) == 0 && (!validateStrict || len(result.Warnings()) == 0),
+		"issues": issues,
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(out)
+}
+
+// agentLineNumbers does a best-effort parse of the raw YAML to recover the
+// line each agent's "id:" key appears on, for error/warning messages. It
+// never fails the command: a parse error here just means issues are
+// reported without line numbers.
+func agentLineNumbers(workflowFile string) map[string]int {
+	lines := make(map[string]int)
+
+	data, err := os.ReadFile(workflowFile)
+	if err != nil {
+		return lines
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return lines
+	}
+	if len(root.Content) == 0 {
+		return lines
+	}
+
+	doc := root.Content[0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "agents" {
+			continue
+		}
+		for _, agentNode := range doc.Content[i+1].Content {
+			var id string
+			var idLine int
+			for j := 0; j+1 < len(agentNode.Content); j += 2 {
+				if agentNode.Content[j].Value == "id" {
+					id = agentNode.Content[j+1].Value
+					idLine = agentNode.Content[j+1].Line
+				}
+			}
+			if id != "" {
+				lines[id] = idLine
+			}
+		}
+	}
+
+	return lines
+}
+
 func init() {
 	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Treat warnings (unused agents, unused shared-memory keys) as errors")
+	validateCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text or json")
 }
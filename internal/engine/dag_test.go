@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"Orkflow/pkg/types"
+)
+
+func TestDagCycleDetectsCycle(t *testing.T) {
+	steps := []types.Step{
+		{Agent: "a", DependsOn: []string{"b"}},
+		{Agent: "b", DependsOn: []string{"a"}},
+	}
+
+	cycle := dagCycle(steps)
+	if cycle == nil {
+		t.Fatal("dagCycle() = nil, want a detected cycle")
+	}
+}
+
+func TestDagCycleAcyclic(t *testing.T) {
+	steps := []types.Step{
+		{Agent: "a"},
+		{Agent: "b", DependsOn: []string{"a"}},
+		{Agent: "c", DependsOn: []string{"a", "b"}},
+	}
+
+	if cycle := dagCycle(steps); cycle != nil {
+		t.Errorf("dagCycle() = %v, want nil for an acyclic graph", cycle)
+	}
+}
+
+func TestDagCycleSelfDependency(t *testing.T) {
+	steps := []types.Step{{Agent: "a", DependsOn: []string{"a"}}}
+
+	if cycle := dagCycle(steps); cycle == nil {
+		t.Error("dagCycle() = nil, want a self-dependency to count as a cycle")
+	}
+}
+
+func TestExecuteDAGFailsFastOnCycle(t *testing.T) {
+	config := &types.WorkflowConfig{
+		Agents: []types.Agent{
+			{ID: "a", Model: "m"},
+			{ID: "b", Model: "m"},
+		},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+		Workflow: &types.WorkflowSpec{
+			Type: "dag",
+			Steps: []types.Step{
+				{Agent: "a", DependsOn: []string{"b"}},
+				{Agent: "b", DependsOn: []string{"a"}},
+			},
+		},
+	}
+
+	e := NewExecutor(config)
+	_, err := e.Execute()
+	if err == nil {
+		t.Fatal("Execute() error = nil, want a cycle error before any agent runs")
+	}
+	if !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Execute() error = %q, want it to mention the cycle", err)
+	}
+}
+
+func TestSubstitutePlaceholders(t *testing.T) {
+	outputs := map[string]string{"step-a": "hello"}
+	got := substitutePlaceholders("prefix {{ steps.step-a.output }} suffix", outputs)
+	want := "prefix hello suffix"
+	if got != want {
+		t.Errorf("substitutePlaceholders() = %q, want %q", got, want)
+	}
+}
+
+func TestSubstitutePlaceholdersUnknownStepBecomesEmpty(t *testing.T) {
+	got := substitutePlaceholders("{{ steps.missing.output }}", map[string]string{})
+	if got != "" {
+		t.Errorf("substitutePlaceholders() = %q, want empty string for an unknown step", got)
+	}
+}
@@ -0,0 +1,61 @@
+package engine
+
+import "time"
+
+// EventType identifies the kind of lifecycle notification an Executor emits
+// while running, for consumers that need more than Execute's final return
+// string - currently the TUI in internal/cli/tui.
+type EventType string
+
+const (
+	EventWorkflowStart EventType = "workflow_start"
+	EventWorkflowEnd   EventType = "workflow_end"
+	EventStepStart     EventType = "step_start"
+	EventAgentStart    EventType = "agent_start"
+	EventAgentToken    EventType = "agent_token"
+	EventToolCall      EventType = "tool_call"
+	EventAgentEnd      EventType = "agent_end"
+	EventError         EventType = "error"
+)
+
+// Event is one structured lifecycle notification. Which fields are set
+// depends on Type: Content holds a token's text for EventAgentToken, a
+// tool's output for EventToolCall, a full response for EventAgentEnd, and
+// an error string for EventError; Tool is set only for EventToolCall.
+type Event struct {
+	Type      EventType
+	AgentID   string
+	Role      string
+	Tool      string
+	Content   string
+	Timestamp time.Time
+}
+
+// SetEventSink registers a channel that Execute sends structured Events to
+// as the workflow runs, and wires the underlying Runner's token/tool
+// callbacks to forward onto it. Sends never block - a slow or absent
+// consumer only misses events, it never stalls execution. Call before
+// Execute; unset by default, so behavior is unchanged when no sink is
+// registered.
+func (e *Executor) SetEventSink(sink chan Event) {
+	e.events = sink
+
+	e.Runner.TokenCallback = func(agentID, token string) {
+		e.emit(Event{Type: EventAgentToken, AgentID: agentID, Content: token})
+	}
+	e.Runner.ToolCallback = func(agentID, tool, output string) {
+		e.emit(Event{Type: EventToolCall, AgentID: agentID, Tool: tool, Content: output})
+	}
+}
+
+// emit sends evt to the configured event sink, if any, without blocking.
+func (e *Executor) emit(evt Event) {
+	if e.events == nil {
+		return
+	}
+	evt.Timestamp = time.Now()
+	select {
+	case e.events <- evt:
+	default:
+	}
+}
@@ -1,20 +1,71 @@
 package engine
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"Orkflow/internal/agent"
+	"Orkflow/internal/checkpoint"
+	"Orkflow/internal/logging"
+	"Orkflow/internal/memory"
+	"Orkflow/internal/metrics"
 	"Orkflow/pkg/types"
+
+	"github.com/hashicorp/go-hclog"
 )
 
 type Executor struct {
-	Config *types.WorkflowConfig
-	Runner *agent.Runner
-	State  *State
+	Config          *types.WorkflowConfig
+	Runner          *agent.Runner
+	State           *State
+	Log             hclog.Logger
+	Metrics         *metrics.Metrics
+	CheckpointStore checkpoint.Store
+	RunID           string
+	Stats           *ExecutionStats
+
+	checkpoint   *checkpoint.Checkpoint
+	checkpointMu sync.Mutex // guards checkpoint's maps - executeParallel/executeDAG mark agents from concurrent goroutines
+	events       chan Event
+	aborted      chan struct{}
+	ctx          context.Context // canceled by Abort; the base context every RunAgent call derives from
+	cancel       context.CancelFunc
+}
+
+// Option configures an Executor at construction time.
+type Option func(*Executor)
+
+// WithMetrics attaches a Prometheus metrics collector that is threaded down
+// into the Executor's Runner. When unset, metrics are not recorded.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(e *Executor) {
+		e.Metrics = m
+	}
+}
+
+// WithCheckpointStore enables checkpointing to the given Store. A checkpoint
+// is written after every step, agent completion, and state transition, so a
+// crashed or killed run can be restarted with Resume without re-invoking
+// already-completed agents. When unset, no checkpoint is written.
+func WithCheckpointStore(store checkpoint.Store) Option {
+	return func(e *Executor) {
+		e.CheckpointStore = store
+	}
 }
 
-func NewExecutor(config *types.WorkflowConfig) *Executor {
+// WithRunID pins the run ID used for checkpointing instead of generating a
+// new one. Resume sets this internally when reloading a prior run.
+func WithRunID(runID string) Option {
+	return func(e *Executor) {
+		e.RunID = runID
+	}
+}
+
+func NewExecutor(config *types.WorkflowConfig, opts ...Option) *Executor {
 	totalSteps := 0
 	if config.Workflow != nil {
 		totalSteps = len(config.Workflow.Steps) + len(config.Workflow.Branches)
@@ -23,11 +74,258 @@ func NewExecutor(config *types.WorkflowConfig) *Executor {
 		}
 	}
 
-	return &Executor{
-		Config: config,
-		Runner: agent.NewRunner(config),
-		State:  NewState(totalSteps),
+	log := hclog.New(&hclog.LoggerOptions{
+		Name:  "executor",
+		Level: hclog.Info,
+		Color: hclog.AutoColor,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Executor{
+		Config:  config,
+		State:   NewState(totalSteps),
+		Log:     log,
+		Stats:   NewExecutionStats(),
+		aborted: make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	if e.Metrics != nil {
+		e.Stats.SetExporter(e.Metrics)
+	}
+
+	runnerOpts := []agent.Option{agent.WithLogger(log)}
+	if e.Metrics != nil {
+		runnerOpts = append(runnerOpts, agent.WithMetrics(e.Metrics))
+	}
+	e.Runner = agent.NewRunner(config, runnerOpts...)
+
+	if e.CheckpointStore != nil {
+		if e.RunID == "" {
+			e.RunID = memory.GenerateID()
+		}
+		e.checkpoint = checkpoint.New(e.RunID, "", totalSteps)
+		for _, a := range config.Agents {
+			e.checkpoint.AgentStatus[a.ID] = checkpoint.AgentPending
+		}
+	}
+
+	return e
+}
+
+// Resume reloads a checkpointed run, marks any agent still "running" at the
+// time of the crash as lost, restores its outputs and shared-memory state
+// into this Executor's Runner, and re-executes the workflow — already
+// completed agents are skipped so their (expensive) LLM calls aren't
+// repeated. If every agent the workflow needs is already AgentCompleted,
+// Execute makes no LLM calls at all and the run is a deterministic replay of
+// the checkpointed outputs.
+func (e *Executor) Resume(runID string) (string, error) {
+	cp, err := e.loadCheckpoint(runID)
+	if err != nil {
+		return "", err
+	}
+	cp.MarkLostInFlight()
+	return e.resumeFrom(runID, cp)
+}
+
+// ResumeFrom behaves like Resume, but first forces fromAgentID and every
+// agent after it in the workflow's step order back to AgentPending,
+// discarding their cached output. Use it to deliberately redo a step (and
+// whatever depends on it) even though the checkpoint already recorded it as
+// completed — e.g. after fixing a prompt, or re-running a step whose cached
+// output looked wrong.
+func (e *Executor) ResumeFrom(runID string, fromAgentID string) (string, error) {
+	cp, err := e.loadCheckpoint(runID)
+	if err != nil {
+		return "", err
+	}
+	cp.MarkLostInFlight()
+
+	resetting := false
+	for _, id := range e.stepOrder() {
+		if id == fromAgentID {
+			resetting = true
+		}
+		if resetting {
+			delete(cp.AgentStatus, id)
+			delete(cp.Outputs, id)
+		}
+	}
+
+	return e.resumeFrom(runID, cp)
+}
+
+// Replay reloads a checkpointed run and re-executes it, same as Resume, but
+// first requires every agent the workflow needs to already be
+// AgentCompleted - if any agent is still pending, running, or lost, it
+// refuses and returns an error instead of falling back to running that
+// agent for real. Use it when the caller's contract is "no LLM calls",
+// e.g. --replay, where silently re-running an incomplete step would be a
+// correctness bug, not just a missed optimization.
+func (e *Executor) Replay(runID string) (string, error) {
+	cp, err := e.loadCheckpoint(runID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, id := range e.stepOrder() {
+		if status := cp.AgentStatus[id]; status != checkpoint.AgentCompleted {
+			return "", fmt.Errorf("replay: agent %s is %s, not completed - run without --replay first", id, status)
+		}
+	}
+
+	return e.resumeFrom(runID, cp)
+}
+
+// loadCheckpoint is the Store.Load precondition shared by Resume and
+// ResumeFrom.
+func (e *Executor) loadCheckpoint(runID string) (*checkpoint.Checkpoint, error) {
+	if e.CheckpointStore == nil {
+		return nil, fmt.Errorf("no checkpoint store configured")
+	}
+	return e.CheckpointStore.Load(runID)
+}
+
+// resumeFrom restores cp's outputs and shared-memory state into the
+// Runner's context and re-executes the workflow, skipping any agent cp
+// still records as AgentCompleted.
+func (e *Executor) resumeFrom(runID string, cp *checkpoint.Checkpoint) (string, error) {
+	e.RunID = runID
+	e.checkpoint = cp
+	e.State.CurrentStep = cp.CurrentStep
+
+	for agentID, response := range cp.Outputs {
+		e.Runner.Context.AddOutput(agentID, response)
+	}
+	if e.Runner.SharedMemory != nil {
+		for key, value := range cp.SharedMemory {
+			e.Runner.SharedMemory.Set(key, value)
+		}
+	}
+
+	for agentID, status := range cp.AgentStatus {
+		if status == checkpoint.AgentLost {
+			e.Log.Info("re-enqueueing lost agent", "agent_id", agentID, "run_id", runID)
+		}
+	}
+
+	return e.Execute()
+}
+
+// stepOrder returns every step/branch/then agent ID in the order Execute
+// runs them, so ResumeFrom knows which agents come after a given one.
+func (e *Executor) stepOrder() []string {
+	if e.Config.Workflow == nil {
+		return nil
+	}
+	var ids []string
+	for _, step := range e.Config.Workflow.Steps {
+		ids = append(ids, step.Agent)
+	}
+	ids = append(ids, e.Config.Workflow.Branches...)
+	if e.Config.Workflow.Then != nil {
+		ids = append(ids, e.Config.Workflow.Then.Agent)
+	}
+	return ids
+}
+
+// isCompleted reports whether agentID was already completed in a resumed
+// checkpoint, so the resumed run can skip it instead of re-invoking its LLM.
+// Locked because executeDAG calls this from concurrent per-step goroutines,
+// while executeParallel's branch goroutines update the same checkpoint.
+func (e *Executor) isCompleted(agentID string) bool {
+	e.checkpointMu.Lock()
+	defer e.checkpointMu.Unlock()
+	return e.checkpoint != nil && e.checkpoint.AgentStatus[agentID] == checkpoint.AgentCompleted
+}
+
+// markAgentRunning records that agentID has started, then persists a
+// checkpoint if a store is configured.
+func (e *Executor) markAgentRunning(agentID string) {
+	e.checkpointMu.Lock()
+	if e.checkpoint == nil {
+		e.checkpointMu.Unlock()
+		return
+	}
+	e.checkpoint.AgentStatus[agentID] = checkpoint.AgentRunning
+	e.checkpointMu.Unlock()
+	e.saveCheckpoint()
+}
+
+// markAgentCompleted records that agentID finished successfully, then
+// persists a checkpoint if a store is configured.
+func (e *Executor) markAgentCompleted(agentID string) {
+	e.checkpointMu.Lock()
+	if e.checkpoint == nil {
+		e.checkpointMu.Unlock()
+		return
 	}
+	e.checkpoint.AgentStatus[agentID] = checkpoint.AgentCompleted
+	e.checkpointMu.Unlock()
+	e.saveCheckpoint()
+}
+
+// saveCheckpoint snapshots the Runner's current outputs and shared-memory
+// state and writes it to the configured CheckpointStore. A save failure is
+// logged, not returned, so a broken checkpoint store never fails the run
+// it's trying to protect. Locked for the same reason isCompleted is - it can
+// run concurrently with markAgentRunning/markAgentCompleted from other
+// in-flight branches or dag steps.
+func (e *Executor) saveCheckpoint() {
+	e.checkpointMu.Lock()
+	defer e.checkpointMu.Unlock()
+
+	if e.CheckpointStore == nil || e.checkpoint == nil {
+		return
+	}
+
+	e.checkpoint.Status = e.State.Status.String()
+	e.checkpoint.CurrentStep = e.State.CurrentStep
+	e.checkpoint.Outputs = e.Runner.Context.OutputsByAgent()
+	if e.Runner.SharedMemory != nil {
+		e.checkpoint.SharedMemory = e.Runner.SharedMemory.Snapshot()
+	}
+	if e.State.Error != nil {
+		e.checkpoint.Error = e.State.Error.Error()
+	}
+	e.checkpoint.UpdatedAt = time.Now()
+
+	if err := e.CheckpointStore.Save(e.checkpoint); err != nil {
+		e.Log.Warn("failed to save checkpoint", "run_id", e.RunID, "error", err)
+	}
+}
+
+// startState marks the workflow as running and logs the transition.
+func (e *Executor) startState() {
+	e.State.Start()
+	e.Log.Info("workflow state transition", "state", e.State.Status.String())
+	e.emit(Event{Type: EventWorkflowStart})
+}
+
+// nextStepState advances the step counter and logs the transition.
+func (e *Executor) nextStepState() {
+	e.State.NextStep()
+	e.Log.Debug("workflow step advanced", "current_step", e.State.CurrentStep, "total_steps", e.State.TotalSteps)
+}
+
+// completeState marks the workflow as completed and logs the transition.
+func (e *Executor) completeState() {
+	e.State.Complete()
+	e.Log.Info("workflow state transition", "state", e.State.Status.String())
+	e.emit(Event{Type: EventWorkflowEnd})
+}
+
+// failState marks the workflow as failed and logs the transition.
+func (e *Executor) failState(err error) {
+	e.State.Fail(err)
+	e.Log.Error("workflow state transition", "state", e.State.Status.String(), "error", err)
+	e.emit(Event{Type: EventError, Content: err.Error()})
 }
 
 func (e *Executor) Execute() (string, error) {
@@ -40,37 +338,58 @@ func (e *Executor) Execute() (string, error) {
 		return e.executeSequential()
 	case "parallel":
 		return e.executeParallel()
+	case "dag":
+		return e.executeDAG()
 	default:
 		return "", fmt.Errorf("unknown workflow type: %s", e.Config.Workflow.Type)
 	}
 }
 
 func (e *Executor) executeSequential() (string, error) {
-	e.State.Start()
+	e.startState()
 
 	for _, step := range e.Config.Workflow.Steps {
+		if e.isAborted() {
+			err := fmt.Errorf("workflow aborted")
+			e.failState(err)
+			return "", err
+		}
+
+		if e.isCompleted(step.Agent) {
+			e.Log.Info("skipping already-completed agent", "agent_id", step.Agent)
+			e.nextStepState()
+			continue
+		}
+
 		agentDef := e.Runner.GetAgent(step.Agent)
 		if agentDef == nil {
 			err := fmt.Errorf("agent not found: %s", step.Agent)
-			e.State.Fail(err)
+			e.failState(err)
 			return "", err
 		}
 
-		_, err := e.Runner.RunAgent(agentDef)
+		e.emit(Event{Type: EventStepStart, AgentID: agentDef.ID, Role: agentDef.Role})
+		e.markAgentRunning(agentDef.ID)
+		e.emit(Event{Type: EventAgentStart, AgentID: agentDef.ID, Role: agentDef.Role})
+		response, err := e.Runner.RunAgent(e.ctx, agentDef)
 		if err != nil {
-			e.State.Fail(err)
+			e.failState(err)
+			e.saveCheckpoint()
 			return "", err
 		}
+		e.emit(Event{Type: EventAgentEnd, AgentID: agentDef.ID, Role: agentDef.Role, Content: response})
+		e.markAgentCompleted(agentDef.ID)
 
-		e.State.NextStep()
+		e.nextStepState()
 	}
 
-	e.State.Complete()
+	e.completeState()
+	e.saveCheckpoint()
 	return e.Runner.GetFinalOutput(), nil
 }
 
 func (e *Executor) executeParallel() (string, error) {
-	e.State.Start()
+	e.startState()
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -78,6 +397,11 @@ func (e *Executor) executeParallel() (string, error) {
 	results := make(map[string]string)
 
 	for _, branchID := range e.Config.Workflow.Branches {
+		if e.isCompleted(branchID) {
+			e.Log.Info("skipping already-completed agent", "agent_id", branchID)
+			continue
+		}
+
 		wg.Add(1)
 		go func(id string) {
 			defer wg.Done()
@@ -92,7 +416,9 @@ func (e *Executor) executeParallel() (string, error) {
 				return
 			}
 
-			response, err := e.Runner.RunAgent(agentDef)
+			e.emit(Event{Type: EventAgentStart, AgentID: agentDef.ID, Role: agentDef.Role})
+			e.markAgentRunning(agentDef.ID)
+			response, err := e.Runner.RunAgent(e.ctx, agentDef)
 			mu.Lock()
 			if err != nil {
 				if firstErr == nil {
@@ -102,37 +428,48 @@ func (e *Executor) executeParallel() (string, error) {
 				results[id] = response
 			}
 			mu.Unlock()
+			if err == nil {
+				e.emit(Event{Type: EventAgentEnd, AgentID: agentDef.ID, Role: agentDef.Role, Content: response})
+				e.markAgentCompleted(agentDef.ID)
+			}
 		}(branchID)
 	}
 
 	wg.Wait()
 
 	if firstErr != nil {
-		e.State.Fail(firstErr)
+		e.failState(firstErr)
+		e.saveCheckpoint()
 		return "", firstErr
 	}
 
-	if e.Config.Workflow.Then != nil {
+	if e.Config.Workflow.Then != nil && !e.isCompleted(e.Config.Workflow.Then.Agent) {
 		thenAgent := e.Runner.GetAgent(e.Config.Workflow.Then.Agent)
 		if thenAgent == nil {
 			err := fmt.Errorf("then agent not found: %s", e.Config.Workflow.Then.Agent)
-			e.State.Fail(err)
+			e.failState(err)
 			return "", err
 		}
 
-		_, err := e.Runner.RunAgent(thenAgent)
+		e.emit(Event{Type: EventAgentStart, AgentID: thenAgent.ID, Role: thenAgent.Role})
+		e.markAgentRunning(thenAgent.ID)
+		response, err := e.Runner.RunAgent(e.ctx, thenAgent)
 		if err != nil {
-			e.State.Fail(err)
+			e.failState(err)
+			e.saveCheckpoint()
 			return "", err
 		}
+		e.emit(Event{Type: EventAgentEnd, AgentID: thenAgent.ID, Role: thenAgent.Role, Content: response})
+		e.markAgentCompleted(thenAgent.ID)
 	}
 
-	e.State.Complete()
+	e.completeState()
+	e.saveCheckpoint()
 	return e.Runner.GetFinalOutput(), nil
 }
 
 func (e *Executor) executeSupervisor() (string, error) {
-	e.State.Start()
+	e.startState()
 
 	var rootAgent *types.Agent
 	for i := range e.Config.Agents {
@@ -148,20 +485,331 @@ func (e *Executor) executeSupervisor() (string, error) {
 
 	if rootAgent == nil {
 		err := fmt.Errorf("no root agent found")
-		e.State.Fail(err)
+		e.failState(err)
 		return "", err
 	}
 
-	response, err := e.Runner.RunAgent(rootAgent)
+	if e.isCompleted(rootAgent.ID) {
+		e.Log.Info("skipping already-completed agent", "agent_id", rootAgent.ID)
+		e.completeState()
+		return e.Runner.GetFinalOutput(), nil
+	}
+
+	e.emit(Event{Type: EventAgentStart, AgentID: rootAgent.ID, Role: rootAgent.Role})
+	e.markAgentRunning(rootAgent.ID)
+	response, err := e.Runner.RunAgent(e.ctx, rootAgent)
 	if err != nil {
-		e.State.Fail(err)
+		e.failState(err)
+		e.saveCheckpoint()
 		return "", err
 	}
+	e.emit(Event{Type: EventAgentEnd, AgentID: rootAgent.ID, Role: rootAgent.Role, Content: response})
+	e.markAgentCompleted(rootAgent.ID)
 
-	e.State.Complete()
+	e.completeState()
+	e.saveCheckpoint()
 	return response, nil
 }
 
+// stepOutputPlaceholder matches `{{ steps.<id>.output }}`, the templating a
+// dag step's prompt uses to reference a dependency's result.
+var stepOutputPlaceholder = regexp.MustCompile(`\{\{\s*steps\.([\w-]+)\.output\s*\}\}`)
+
+// substitutePlaceholders replaces every {{ steps.<id>.output }} in text with
+// outputs[id], leaving unmatched placeholders (a dependency that failed
+// under on_error: continue) as an empty string.
+func substitutePlaceholders(text string, outputs map[string]string) string {
+	return stepOutputPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		id := stepOutputPlaceholder.FindStringSubmatch(match)[1]
+		return outputs[id]
+	})
+}
+
+// executeDAG runs Workflow.Steps as a dependency graph instead of a fixed
+// order: a step starts as soon as every ID in its DependsOn has finished,
+// bounded to MaxParallel concurrent agents so an otherwise-wide graph
+// doesn't fire every independent step at once. A finished step's output is
+// substituted into its dependents' prompts via {{ steps.<id>.output }}
+// before they run. Per step, Timeout (a time.ParseDuration string) bounds
+// its context and Retries governs how many attempts it gets; OnError
+// ("continue", "fail" - the default, or "fallback:<agentID>") decides what
+// happens once those retries are exhausted.
+func (e *Executor) executeDAG() (string, error) {
+	e.startState()
+
+	steps := e.Config.Workflow.Steps
+
+	if cycle := dagCycle(steps); cycle != nil {
+		err := fmt.Errorf("dag depends_on cycle detected: %s", strings.Join(cycle, " -> "))
+		e.failState(err)
+		return "", err
+	}
+
+	done := make(map[string]chan struct{}, len(steps))
+	for _, step := range steps {
+		done[step.Agent] = make(chan struct{})
+	}
+
+	maxParallel := e.Config.Workflow.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = len(steps)
+	}
+	sem := make(chan struct{}, maxParallel)
+
+	outputs := make(map[string]string)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for _, step := range steps {
+		step := step // capture this iteration's step, not the loop variable
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[step.Agent])
+
+			for _, dep := range step.DependsOn {
+				if ch, ok := done[dep]; ok {
+					<-ch
+				}
+			}
+
+			if e.isCompleted(step.Agent) {
+				e.Log.Info("skipping already-completed agent", "agent_id", step.Agent)
+				e.checkpointMu.Lock()
+				cached := e.checkpoint.Outputs[step.Agent]
+				e.checkpointMu.Unlock()
+				mu.Lock()
+				outputs[step.Agent] = cached
+				mu.Unlock()
+				return
+			}
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mu.Lock()
+			aborted := firstErr != nil
+			mu.Unlock()
+			if aborted || e.isAborted() {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("workflow aborted")
+				}
+				mu.Unlock()
+				return
+			}
+
+			agentDef := e.Runner.GetAgent(step.Agent)
+			if agentDef == nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("agent not found: %s", step.Agent)
+				}
+				mu.Unlock()
+				return
+			}
+
+			resolved := *agentDef
+			mu.Lock()
+			resolved.Instruction = substitutePlaceholders(resolved.Instruction, outputs)
+			resolved.Goal = substitutePlaceholders(resolved.Goal, outputs)
+			mu.Unlock()
+
+			ctx := e.ctx
+			if step.Timeout != "" {
+				if d, perr := time.ParseDuration(step.Timeout); perr == nil {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, d)
+					defer cancel()
+				} else {
+					e.Log.Warn("invalid dag step timeout, ignoring", "agent_id", step.Agent, "timeout", step.Timeout, "error", perr)
+				}
+			}
+
+			retries := step.Retries
+			if retries <= 0 {
+				retries = 1
+			}
+
+			e.emit(Event{Type: EventStepStart, AgentID: agentDef.ID, Role: agentDef.Role})
+			e.markAgentRunning(agentDef.ID)
+			e.emit(Event{Type: EventAgentStart, AgentID: agentDef.ID, Role: agentDef.Role})
+
+			stepStart := time.Now()
+			var response string
+			var err error
+			for attempt := 1; attempt <= retries; attempt++ {
+				response, err = e.Runner.RunAgent(ctx, &resolved)
+				if err == nil {
+					break
+				}
+				e.Log.Warn("dag step attempt failed", "agent_id", agentDef.ID, "attempt", attempt, "error", err)
+			}
+			e.State.RecordStepDuration(agentDef.ID, time.Since(stepStart))
+
+			if err != nil {
+				switch {
+				case step.OnError == "continue":
+					e.Log.Warn("dag step failed, continuing past it", "agent_id", agentDef.ID, "error", err)
+					mu.Lock()
+					outputs[agentDef.ID] = ""
+					mu.Unlock()
+					e.markAgentCompleted(agentDef.ID)
+					return
+				case strings.HasPrefix(step.OnError, "fallback:"):
+					fallbackID := strings.TrimPrefix(step.OnError, "fallback:")
+					fallbackDef := e.Runner.GetAgent(fallbackID)
+					if fallbackDef == nil {
+						mu.Lock()
+						if firstErr == nil {
+							firstErr = fmt.Errorf("dag step %s: fallback agent not found: %s", step.Agent, fallbackID)
+						}
+						mu.Unlock()
+						return
+					}
+					response, err = e.Runner.RunAgent(ctx, fallbackDef)
+					if err != nil {
+						mu.Lock()
+						if firstErr == nil {
+							firstErr = err
+						}
+						mu.Unlock()
+						return
+					}
+				default: // "fail", or unset
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					return
+				}
+			}
+
+			mu.Lock()
+			outputs[agentDef.ID] = response
+			mu.Unlock()
+
+			e.emit(Event{Type: EventAgentEnd, AgentID: agentDef.ID, Role: agentDef.Role, Content: response})
+			e.markAgentCompleted(agentDef.ID)
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		e.failState(firstErr)
+		e.saveCheckpoint()
+		return "", firstErr
+	}
+
+	e.completeState()
+	e.saveCheckpoint()
+	return e.Runner.GetFinalOutput(), nil
+}
+
+// dagCycle walks steps' DependsOn graph and returns the agent IDs forming a
+// cycle, or nil if it's acyclic. executeDAG's goroutines each block on
+// <-done[dep] for every DependsOn entry, so a cycle would otherwise hang the
+// run forever instead of failing - this is the same DFS
+// (agent.Runner.findRequiresCycle) orka validate already runs over the
+// requires/outputs graph, applied to depends_on instead.
+func dagCycle(steps []types.Step) []string {
+	edges := make(map[string][]string, len(steps)) // agent ID -> its DependsOn IDs
+	for _, step := range steps {
+		edges[step.Agent] = step.DependsOn
+	}
+
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+	state := make(map[string]int, len(steps))
+	var path []string
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, dep := range edges[id] {
+			switch state[dep] {
+			case visiting:
+				return append(append([]string{}, path...), dep)
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, step := range steps {
+		if state[step.Agent] == unvisited {
+			if cycle := visit(step.Agent); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
 func (e *Executor) GetState() *State {
 	return e.State
 }
+
+// AddSink attaches another Handler the Runner's execution log fans out to -
+// a text file, an NDJSON file, a live HTTPHandler, or any combination,
+// folding each into a MultiHandler as it's added. When none has been added,
+// nothing is logged to disk.
+func (e *Executor) AddSink(handler logging.Handler) {
+	if e.Runner.Logger == nil || e.Runner.Logger.Handler == nil {
+		e.Runner.Logger = logging.New(handler)
+		return
+	}
+	e.Runner.Logger.Handler = logging.NewMultiHandler(e.Runner.Logger.Handler, handler)
+}
+
+// SetSessionHistory forwards prior session context down to the Runner, so
+// it's included in every agent's prompt.
+func (e *Executor) SetSessionHistory(history string) {
+	e.Runner.SetSessionHistory(history)
+}
+
+// SetMessageCallback forwards a callback the Runner invokes whenever an
+// agent completes, so callers (session persistence, the TUI) can observe
+// each response as it happens rather than only the final Execute result.
+func (e *Executor) SetMessageCallback(cb func(agentID, role, content string)) {
+	e.Runner.MessageCallback = cb
+}
+
+// Abort requests that the run stop before starting its next agent, and
+// cancels the context every in-flight RunAgent call derives from, so an
+// LLMClient.Generate that respects ctx is interrupted rather than run to
+// completion. Safe to call more than once.
+func (e *Executor) Abort() {
+	select {
+	case <-e.aborted:
+	default:
+		close(e.aborted)
+	}
+	e.cancel()
+}
+
+// isAborted reports whether Abort has been called.
+func (e *Executor) isAborted() bool {
+	select {
+	case <-e.aborted:
+		return true
+	default:
+		return false
+	}
+}
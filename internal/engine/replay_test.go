@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"Orkflow/internal/checkpoint"
+	"Orkflow/pkg/types"
+)
+
+func sequentialConfig() *types.WorkflowConfig {
+	return &types.WorkflowConfig{
+		Agents: []types.Agent{
+			{ID: "a", Model: "m"},
+			{ID: "b", Model: "m"},
+		},
+		Models: map[string]types.Model{"m": {Provider: "openai"}},
+		Workflow: &types.WorkflowSpec{
+			Type:  "sequential",
+			Steps: []types.Step{{Agent: "a"}, {Agent: "b"}},
+		},
+	}
+}
+
+func TestReplayRefusesPendingAgent(t *testing.T) {
+	store := checkpoint.NewFSStore(t.TempDir())
+	config := sequentialConfig()
+
+	cp := checkpoint.New("run-1", "", 2)
+	cp.AgentStatus["a"] = checkpoint.AgentCompleted
+	cp.AgentStatus["b"] = checkpoint.AgentPending
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	e := NewExecutor(config, WithCheckpointStore(store))
+	_, err := e.Replay("run-1")
+	if err == nil {
+		t.Fatal("Replay() error = nil, want a refusal since agent b is still pending")
+	}
+	if !strings.Contains(err.Error(), "agent b is pending") {
+		t.Errorf("Replay() error = %q, want it to name agent b as pending", err)
+	}
+}
+
+func TestReplayRefusesLostAgent(t *testing.T) {
+	store := checkpoint.NewFSStore(t.TempDir())
+	config := sequentialConfig()
+
+	cp := checkpoint.New("run-1", "", 2)
+	cp.AgentStatus["a"] = checkpoint.AgentCompleted
+	cp.AgentStatus["b"] = checkpoint.AgentLost
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	e := NewExecutor(config, WithCheckpointStore(store))
+	if _, err := e.Replay("run-1"); err == nil {
+		t.Fatal("Replay() error = nil, want a refusal since agent b is lost, not completed")
+	}
+}
+
+func TestReplayRunsWhenEveryAgentCompleted(t *testing.T) {
+	store := checkpoint.NewFSStore(t.TempDir())
+	config := sequentialConfig()
+
+	cp := checkpoint.New("run-1", "", 2)
+	cp.AgentStatus["a"] = checkpoint.AgentCompleted
+	cp.AgentStatus["b"] = checkpoint.AgentCompleted
+	cp.Outputs["a"] = "output-a"
+	cp.Outputs["b"] = "output-b"
+	if err := store.Save(cp); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	e := NewExecutor(config, WithCheckpointStore(store))
+	if _, err := e.Replay("run-1"); err != nil {
+		t.Fatalf("Replay() error = %v, want nil when every agent is already completed", err)
+	}
+}
+
+func TestReplayWithoutCheckpointStoreErrors(t *testing.T) {
+	e := NewExecutor(sequentialConfig())
+	if _, err := e.Replay("run-1"); err == nil {
+		t.Fatal("Replay() error = nil, want an error when no checkpoint store is configured")
+	}
+}
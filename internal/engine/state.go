@@ -1,5 +1,10 @@
 package engine
 
+import (
+	"sync"
+	"time"
+)
+
 type WorkflowState int
 
 const (
@@ -29,16 +34,32 @@ type State struct {
 	CurrentStep int
 	TotalSteps  int
 	Error       error
+
+	stepDurationsMu sync.Mutex
+	// StepDurations records how long each completed step/agent took, keyed
+	// by agent ID - populated by the dag workflow type, whose steps run
+	// concurrently and so can't rely on CurrentStep's sequential ordering
+	// to convey per-step timing the way executeSequential's step count does.
+	StepDurations map[string]time.Duration
 }
 
 func NewState(totalSteps int) *State {
 	return &State{
-		Status:      StatePending,
-		CurrentStep: 0,
-		TotalSteps:  totalSteps,
+		Status:        StatePending,
+		CurrentStep:   0,
+		TotalSteps:    totalSteps,
+		StepDurations: make(map[string]time.Duration),
 	}
 }
 
+// RecordStepDuration stores how long agentID's step took. Safe to call
+// concurrently from multiple in-flight dag steps.
+func (s *State) RecordStepDuration(agentID string, d time.Duration) {
+	s.stepDurationsMu.Lock()
+	defer s.stepDurationsMu.Unlock()
+	s.StepDurations[agentID] = d
+}
+
 func (s *State) Start() {
 	s.Status = StateRunning
 }
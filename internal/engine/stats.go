@@ -3,9 +3,16 @@ package engine
 import (
 	"sync"
 	"time"
+
+	"Orkflow/internal/logging"
+	"Orkflow/internal/metrics"
+	"Orkflow/internal/pricing"
 )
 
-// ExecutionStats tracks timing and cost for a workflow run
+// ExecutionStats tracks timing and cost for a workflow run, and mirrors the
+// same signals into an Exporter (Prometheus/OTLP/no-op) as they happen, so a
+// long-running deployment doesn't have to wait for the run to finish to see
+// them.
 type ExecutionStats struct {
 	mu          sync.Mutex
 	StartTime   time.Time
@@ -14,12 +21,16 @@ type ExecutionStats struct {
 		Input  int
 		Output int
 	}
+
+	exporter metrics.Exporter
+	inflight int
 }
 
 // AgentStat tracks per-agent statistics
 type AgentStat struct {
 	AgentID      string
 	Role         string
+	Provider     string
 	Model        string
 	StartTime    time.Time
 	Duration     time.Duration
@@ -28,25 +39,56 @@ type AgentStat struct {
 	Completed    bool
 }
 
+// StatsOption configures an ExecutionStats at construction time.
+type StatsOption func(*ExecutionStats)
+
+// WithExporter attaches an Exporter that every StartAgent/CompleteAgent call
+// also reports through. Defaults to metrics.NoopExporter{}.
+func WithExporter(exporter metrics.Exporter) StatsOption {
+	return func(s *ExecutionStats) {
+		s.exporter = exporter
+	}
+}
+
 // NewExecutionStats creates a new stats tracker
-func NewExecutionStats() *ExecutionStats {
-	return &ExecutionStats{
+func NewExecutionStats(opts ...StatsOption) *ExecutionStats {
+	s := &ExecutionStats{
 		StartTime:  time.Now(),
 		AgentStats: make(map[string]*AgentStat),
+		exporter:   metrics.NoopExporter{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
+}
+
+// SetExporter swaps the Exporter used by subsequent StartAgent/CompleteAgent
+// calls, for callers (like Executor) that only know which exporter to use
+// after construction.
+func (s *ExecutionStats) SetExporter(exporter metrics.Exporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exporter = exporter
 }
 
 // StartAgent marks an agent as started
-func (s *ExecutionStats) StartAgent(agentID, role, model string) {
+func (s *ExecutionStats) StartAgent(agentID, role, provider, model string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.AgentStats[agentID] = &AgentStat{
 		AgentID:   agentID,
 		Role:      role,
+		Provider:  provider,
 		Model:     model,
 		StartTime: time.Now(),
 	}
+
+	s.inflight++
+	s.exporter.SetAgentsInflight(s.inflight)
 }
 
 // CompleteAgent marks an agent as completed with token counts
@@ -62,6 +104,13 @@ func (s *ExecutionStats) CompleteAgent(agentID string, inputTokens, outputTokens
 
 		s.TotalTokens.Input += inputTokens
 		s.TotalTokens.Output += outputTokens
+
+		s.inflight--
+		s.exporter.RecordAgentDuration(stat.AgentID, stat.Role, stat.Model, stat.Duration)
+		s.exporter.RecordAgentTokens(stat.Model, "input", inputTokens)
+		s.exporter.RecordAgentTokens(stat.Model, "output", outputTokens)
+		s.exporter.IncAgentsCompleted()
+		s.exporter.SetAgentsInflight(s.inflight)
 	}
 }
 
@@ -84,29 +133,33 @@ func (s *ExecutionStats) GetCompletedCount() int {
 	return count
 }
 
-// EstimateCost calculates estimated cost based on token usage and model
-func (s *ExecutionStats) EstimateCost() float64 {
+// EstimateCost prices out every agent's recorded token usage against
+// catalog, returning a breakdown by agent and by model. A model absent from
+// catalog contributes nothing to TotalCost but is recorded in
+// MissingModels, and a warning is logged through logging.CLI rather than
+// silently returning an understated cost.
+func (s *ExecutionStats) EstimateCost(catalog *pricing.Catalog) *pricing.CostReport {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Pricing per 1M tokens (input/output)
-	pricing := map[string]struct{ Input, Output float64 }{
-		"gpt-4o":           {2.50, 10.00},
-		"gpt-4o-mini":      {0.15, 0.60},
-		"gpt-4-turbo":      {10.00, 30.00},
-		"gpt-3.5-turbo":    {0.50, 1.50},
-		"gemini-2.0-flash": {0.075, 0.30},
-		"gemini-1.5-pro":   {1.25, 5.00},
-	}
+	report := pricing.NewCostReport()
 
-	var totalCost float64
 	for _, stat := range s.AgentStats {
-		if p, ok := pricing[stat.Model]; ok {
-			inputCost := float64(stat.InputTokens) / 1000000 * p.Input
-			outputCost := float64(stat.OutputTokens) / 1000000 * p.Output
-			totalCost += inputCost + outputCost
+		entry, ok := catalog.Lookup(stat.Provider, stat.Model)
+		if !ok {
+			report.AddMissing(stat.Model)
+			logging.CLI.WithFields(logging.F{"agent_id": stat.AgentID, "provider": stat.Provider, "model": stat.Model}).
+				Warn("no pricing entry for model, cost omitted from estimate")
+			continue
 		}
+
+		inputCost := float64(stat.InputTokens) / 1000000 * entry.Input
+		outputCost := float64(stat.OutputTokens) / 1000000 * entry.Output
+		cost := inputCost + outputCost + entry.PerRequest
+
+		report.Add(stat.AgentID, stat.Model, cost)
+		s.exporter.RecordAgentCost(stat.Model, cost)
 	}
 
-	return totalCost
+	return report
 }
@@ -0,0 +1,23 @@
+package logging
+
+import "os"
+
+// CLI is the process-wide logger for terminal output, backed by a
+// CLIHandler writing to stderr. Commands that want colorized, leveled
+// console logging use this instead of constructing their own handler;
+// SetVerbose raises it to DebugLevel for the --verbose flag.
+var CLI = New(NewCLIHandler(os.Stderr))
+
+// SetVerbose raises or lowers CLI's handler level between Info and Debug.
+// cli.root's --verbose flag calls this once at startup.
+func SetVerbose(verbose bool) {
+	handler, ok := CLI.Handler.(*CLIHandler)
+	if !ok {
+		return
+	}
+	if verbose {
+		handler.Level = DebugLevel
+	} else {
+		handler.Level = InfoLevel
+	}
+}
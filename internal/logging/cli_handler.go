@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ANSI color codes for CLIHandler output. Kept local (rather than imported
+// from internal/cli) to avoid a package cycle, since internal/cli imports
+// logging.
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorCyan   = "\033[36m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+	colorBold   = "\033[1m"
+)
+
+func levelColor(l Level) string {
+	switch l {
+	case DebugLevel:
+		return colorGray
+	case InfoLevel:
+		return colorCyan
+	case WarnLevel:
+		return colorYellow
+	case ErrorLevel, FatalLevel:
+		return colorBold + colorRed
+	default:
+		return colorReset
+	}
+}
+
+// CLIHandler writes colorized entries to an io.Writer (normally os.Stderr),
+// filtering out anything below Level. The --verbose flag raises Level to
+// DebugLevel so agent-internal detail only shows up when asked for.
+type CLIHandler struct {
+	mu    sync.Mutex
+	w     io.Writer
+	Level Level
+}
+
+// NewCLIHandler creates a CLIHandler writing to w at InfoLevel.
+func NewCLIHandler(w io.Writer) *CLIHandler {
+	return &CLIHandler{w: w, Level: InfoLevel}
+}
+
+func (h *CLIHandler) HandleLog(e *Entry) error {
+	if e.Level < h.Level {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	line := fmt.Sprintf("%s%-5s%s %s", levelColor(e.Level), e.Level.String(), colorReset, e.Message)
+	if fields := formatFields(e.Fields); fields != "" {
+		line += colorGray + " " + fields + colorReset
+	}
+	_, err := fmt.Fprintln(h.w, line)
+	return err
+}
+
+// MultiHandler fans an Entry out to every Handler in order, so one log call
+// can land in a file, a JSON stream, and the terminal at once. It returns
+// the first error encountered, after still attempting every handler.
+type MultiHandler struct {
+	Handlers []Handler
+}
+
+// NewMultiHandler combines handlers into one.
+func NewMultiHandler(handlers ...Handler) *MultiHandler {
+	return &MultiHandler{Handlers: handlers}
+}
+
+func (h *MultiHandler) HandleLog(e *Entry) error {
+	var firstErr error
+	for _, handler := range h.Handlers {
+		if err := handler.HandleLog(e); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
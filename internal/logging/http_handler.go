@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// HTTPHandler is a Handler that fans every Entry out to connected Server-Sent
+// Events clients, and separately serves past runs back from logDir for
+// replay. It's the sink behind `orka run --log-serve`, meant to be added
+// alongside (not instead of) a file-based Handler via Executor.AddSink.
+type HTTPHandler struct {
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+
+	logDir string
+}
+
+// NewHTTPHandler creates an HTTPHandler that replays session logs from
+// logDir (~/.orka/logs by default).
+func NewHTTPHandler(logDir string) *HTTPHandler {
+	if logDir == "" {
+		home, _ := os.UserHomeDir()
+		logDir = filepath.Join(home, ".orka", "logs")
+	}
+	return &HTTPHandler{
+		clients: make(map[chan []byte]struct{}),
+		logDir:  logDir,
+	}
+}
+
+func (h *HTTPHandler) HandleLog(e *Entry) error {
+	line, err := json.Marshal(jsonRecord{
+		Level:   e.Level.String(),
+		Message: e.Message,
+		Time:    e.Time,
+		Fields:  e.Fields,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		select {
+		case c <- line:
+		default: // a slow client drops events rather than blocking the run
+		}
+	}
+	return nil
+}
+
+// Handler returns the http.Handler serving /events (a live SSE stream of
+// every Entry handled from here on) and /sessions/{id}/logs (a replay of a
+// past run's NDJSON log file from logDir). Mount it directly, e.g.
+// http.ListenAndServe(addr, h.Handler()).
+func (h *HTTPHandler) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", h.serveEvents)
+	mux.HandleFunc("/sessions/", h.serveSessionLogs)
+	return mux
+}
+
+func (h *HTTPHandler) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan []byte, 16)
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, client)
+		h.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-client:
+			fmt.Fprintf(w, "data: %s\n\n", line)
+			flusher.Flush()
+		}
+	}
+}
+
+// serveSessionLogs handles GET /sessions/{id}/logs, streaming back the
+// NDJSON log file matching {timestamp}_{id}.ndjson in logDir - the file
+// NewNDJSONLogger wrote for that session - as application/x-ndjson. It
+// returns 404 if no such file exists.
+func (h *HTTPHandler) serveSessionLogs(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/sessions/"), "/logs")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	matches, err := filepath.Glob(filepath.Join(h.logDir, "*_"+id+".ndjson"))
+	if err != nil || len(matches) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(matches[len(matches)-1])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	io.Copy(w, file)
+}
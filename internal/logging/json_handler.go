@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// jsonRecord is the on-disk shape of a JSONHandler line.
+type jsonRecord struct {
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+	Fields  Fields    `json:"fields,omitempty"`
+}
+
+// JSONHandler writes one JSON object per Entry, newline-delimited, for
+// ingestion by log pipelines like Loki or ELK.
+type JSONHandler struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONHandler writes entries to w as they arrive.
+func NewJSONHandler(w io.Writer) *JSONHandler {
+	return &JSONHandler{enc: json.NewEncoder(w)}
+}
+
+func (h *JSONHandler) HandleLog(e *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.enc.Encode(jsonRecord{
+		Level:   e.Level.String(),
+		Message: e.Message,
+		Time:    e.Time,
+		Fields:  e.Fields,
+	})
+}
+
+// NewNDJSONLogger creates a Logger that writes newline-delimited JSON to a
+// timestamped file under logDir (~/.orka/logs by default). Unlike NewLogger
+// it writes no box-drawing header/footer - every line is a self-contained
+// JSON record, so a consumer (jq, the HTTPHandler replay endpoint) can read
+// the file without stripping decoration first. This is the constructor
+// `orka run --log-format ndjson` reaches for.
+func NewNDJSONLogger(sessionID string, logDir string) (*Logger, error) {
+	if logDir == "" {
+		home, _ := os.UserHomeDir()
+		logDir = filepath.Join(home, ".orka", "logs")
+	}
+
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := fmt.Sprintf("%s_%s.ndjson", timestamp, sessionID)
+	filePath := filepath.Join(logDir, filename)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log file: %w", err)
+	}
+
+	return &Logger{
+		Handler:  NewJSONHandler(file),
+		file:     file,
+		filePath: filePath,
+	}, nil
+}
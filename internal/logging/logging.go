@@ -0,0 +1,180 @@
+// Package logging provides a structured, leveled logger (in the spirit of
+// apex/log) with pluggable Handlers: the same Entry can land in a
+// human-readable file, a JSON stream for Loki/ELK, and a colorized stderr
+// view, all from one call site.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is a log entry's severity, ordered Debug < Info < Warn < Error < Fatal.
+type Level int
+
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+)
+
+// String returns the lowercase level name, e.g. "info".
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// Fields are arbitrary structured key/value pairs attached to an Entry. F is
+// shorthand for inline use, e.g. log.WithFields(F{"agent": id}).Info("started").
+type Fields map[string]interface{}
+
+// F is shorthand for Fields.
+type F = Fields
+
+// mergeFields combines base and extra into a new Fields value, with extra
+// taking precedence on key collisions. Returns nil if both are empty, so an
+// Entry with no fields at all doesn't carry an allocated empty map.
+func mergeFields(base, extra Fields) Fields {
+	if len(base) == 0 && len(extra) == 0 {
+		return nil
+	}
+	merged := make(Fields, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// formatFields renders fields as "key=value" pairs sorted by key, for the
+// text and CLI handlers.
+func formatFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Entry is a single structured log record: a level, a message, the time it
+// was produced, and whatever Fields were attached via WithFields.
+type Entry struct {
+	Level   Level
+	Message string
+	Time    time.Time
+	Fields  Fields
+
+	logger *Logger
+}
+
+// WithFields returns a new Entry with fields merged on top of e's existing
+// ones, so chains like log.WithFields(F{"a": 1}).WithFields(F{"b": 2}) both
+// end up on the final entry.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	return &Entry{logger: e.logger, Fields: mergeFields(e.Fields, fields)}
+}
+
+func (e *Entry) Debug(msg string) { e.logger.log(DebugLevel, msg, e.Fields) }
+func (e *Entry) Info(msg string)  { e.logger.log(InfoLevel, msg, e.Fields) }
+func (e *Entry) Warn(msg string)  { e.logger.log(WarnLevel, msg, e.Fields) }
+func (e *Entry) Error(msg string) { e.logger.log(ErrorLevel, msg, e.Fields) }
+
+// Fatal logs at FatalLevel and then exits the process, matching the
+// convention of apex/log and the standard library's log.Fatal.
+func (e *Entry) Fatal(msg string) {
+	e.logger.log(FatalLevel, msg, e.Fields)
+	os.Exit(1)
+}
+
+// Handler processes a finished Entry - writing it to a file, stdout, a
+// remote sink, or anything else. A Handler decides for itself whether to
+// act on an Entry's Level (see CLIHandler's verbose gating); Logger itself
+// applies no filtering so the same Entry can be handled differently by each
+// sink in a MultiHandler.
+type Handler interface {
+	HandleLog(*Entry) error
+}
+
+// Logger dispatches Entries to a Handler, optionally preloaded with base
+// Fields that every Entry it produces inherits. The zero Logger (no
+// Handler) is a valid no-op logger, the same role the old NullLogger played.
+type Logger struct {
+	mu      sync.Mutex
+	Handler Handler
+	Fields  Fields
+
+	file     *os.File // set only by NewLogger, for header/footer + Close
+	filePath string
+}
+
+// New creates a Logger that dispatches to handler.
+func New(handler Handler) *Logger {
+	return &Logger{Handler: handler}
+}
+
+// WithFields returns an Entry bound to l with the given fields, ready for
+// chaining: log.WithFields(F{"agent": id}).Info("started").
+func (l *Logger) WithFields(fields Fields) *Entry {
+	return &Entry{logger: l, Fields: mergeFields(l.Fields, fields)}
+}
+
+func (l *Logger) log(level Level, msg string, fields Fields) {
+	if l == nil || l.Handler == nil {
+		return
+	}
+
+	entry := &Entry{Level: level, Message: msg, Time: time.Now(), Fields: fields}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.Handler.HandleLog(entry)
+}
+
+func (l *Logger) Debug(msg string) { l.log(DebugLevel, msg, l.Fields) }
+func (l *Logger) Info(msg string)  { l.log(InfoLevel, msg, l.Fields) }
+func (l *Logger) Warn(msg string)  { l.log(WarnLevel, msg, l.Fields) }
+func (l *Logger) Error(msg string) { l.log(ErrorLevel, msg, l.Fields) }
+
+// Fatal logs at FatalLevel and then exits the process.
+func (l *Logger) Fatal(msg string) {
+	l.log(FatalLevel, msg, l.Fields)
+	os.Exit(1)
+}
+
+// truncate shortens a string for logging.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "..."
+}
@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TextHandler renders Entries as one human-readable line per record,
+// "[HH:MM:SS] message key=value ...". It's the plain-text sibling of
+// JSONHandler, suited to a file a person will actually read.
+type TextHandler struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewTextHandler writes entries to w as they arrive.
+func NewTextHandler(w io.Writer) *TextHandler {
+	return &TextHandler{w: w}
+}
+
+func (h *TextHandler) HandleLog(e *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	line := fmt.Sprintf("[%s] %s", e.Time.Format("15:04:05"), e.Message)
+	if fields := formatFields(e.Fields); fields != "" {
+		line += " " + fields
+	}
+	_, err := fmt.Fprintln(h.w, line)
+	return err
+}
+
+// NewLogger creates a Logger that writes to a timestamped file under logDir
+// (~/.orka/logs by default), wrapped in the pretty box-drawing header/footer
+// the original file-based execution logger used. This is the constructor
+// `orka run --log` reaches for.
+func NewLogger(sessionID string, logDir string) (*Logger, error) {
+	if logDir == "" {
+		home, _ := os.UserHomeDir()
+		logDir = filepath.Join(home, ".orka", "logs")
+	}
+
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := fmt.Sprintf("%s_%s.log", timestamp, sessionID)
+	filePath := filepath.Join(logDir, filename)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log file: %w", err)
+	}
+
+	writeFileHeader(file, sessionID)
+
+	return &Logger{
+		Handler:  NewTextHandler(file),
+		file:     file,
+		filePath: filePath,
+	}, nil
+}
+
+func writeFileHeader(w io.Writer, sessionID string) {
+	header := fmt.Sprintf(`╔══════════════════════════════════════════════════════════════╗
+║                    ORKFLOW EXECUTION LOG                     ║
+╠══════════════════════════════════════════════════════════════╣
+║  Session: %-50s ║
+║  Started: %-50s ║
+╚══════════════════════════════════════════════════════════════╝
+
+`, sessionID, time.Now().Format("2006-01-02 15:04:05"))
+
+	io.WriteString(w, header)
+}
+
+// GetFilePath returns the log file path, or "" for a Logger not created by
+// NewLogger (e.g. one built directly with New for a non-file handler).
+func (l *Logger) GetFilePath() string {
+	return l.filePath
+}
+
+// Close writes the closing footer (for a TextHandler-backed Logger; NDJSON
+// and other file formats get no decoration, since a footer line would break
+// line-oriented parsing) and closes the underlying log file. It is a no-op
+// for a Logger not created by NewLogger/NewNDJSONLogger.
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
+	}
+
+	if _, ok := l.Handler.(*TextHandler); ok {
+		footer := fmt.Sprintf("\n╔══════════════════════════════════════════════════════════════╗\n║  Completed: %-48s ║\n╚══════════════════════════════════════════════════════════════╝\n", time.Now().Format("2006-01-02 15:04:05"))
+		l.file.WriteString(footer)
+	}
+
+	return l.file.Close()
+}
+
+// LogAgent logs an agent lifecycle event, attaching agent_id and event as
+// fields so a JSONHandler consumer can filter on them without parsing text.
+func (l *Logger) LogAgent(agentID, event, details string) {
+	l.log(InfoLevel, fmt.Sprintf("%s: %s", event, details), Fields{"agent_id": agentID, "event": event})
+}
+
+// LogSection writes an Info-level section marker.
+func (l *Logger) LogSection(title string) {
+	l.log(InfoLevel, title, Fields{"section": title})
+}
+
+// LogAgentOutput logs the full output produced by an agent.
+func (l *Logger) LogAgentOutput(agentID, role, output string) {
+	l.log(InfoLevel, output, Fields{"agent_id": agentID, "role": role})
+}
+
+// LogError logs err at Error level.
+func (l *Logger) LogError(err error) {
+	l.log(ErrorLevel, err.Error(), nil)
+}
+
+// LogToolCall logs a tool invocation's input and output, each truncated so a
+// large payload doesn't blow up the log.
+func (l *Logger) LogToolCall(toolName, input, output string) {
+	l.log(InfoLevel, fmt.Sprintf("tool call: %s", toolName), Fields{
+		"tool":   toolName,
+		"input":  truncate(input, 100),
+		"output": truncate(output, 200),
+	})
+}
+
+// Log writes a printf-style Info-level message with no fields, for existing
+// call sites that haven't moved to structured fields.
+func (l *Logger) Log(format string, args ...interface{}) {
+	l.log(InfoLevel, fmt.Sprintf(format, args...), nil)
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os/exec"
 	"sync"
+	"time"
 
+	"Orkflow/internal/metrics"
+
+	"github.com/hashicorp/go-hclog"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -23,6 +27,8 @@ type Client struct {
 	client  *mcp.Client
 	ctx     context.Context
 	cancel  context.CancelFunc
+	log     hclog.Logger
+	metrics *metrics.Metrics
 }
 
 type mcpServer struct {
@@ -31,8 +37,27 @@ type mcpServer struct {
 	tools   []*mcp.Tool
 }
 
+// Option configures a Client at construction time.
+type Option func(*Client)
+
+// WithLogger sets the structured logger used for connect/list-tools events.
+// Defaults to a colorized human-readable logger at Info level.
+func WithLogger(log hclog.Logger) Option {
+	return func(c *Client) {
+		c.log = log
+	}
+}
+
+// WithMetrics attaches a Prometheus metrics collector used to record MCP
+// tool call durations. When unset, metrics are not recorded.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
 // NewClient creates a new MCP client manager
-func NewClient() *Client {
+func NewClient(opts ...Option) *Client {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create a single MCP client instance
@@ -41,12 +66,23 @@ func NewClient() *Client {
 		Version: "1.0.0",
 	}, nil)
 
-	return &Client{
+	c := &Client{
 		servers: make(map[string]*mcpServer),
 		client:  client,
 		ctx:     ctx,
 		cancel:  cancel,
+		log: hclog.New(&hclog.LoggerOptions{
+			Name:  "mcp",
+			Level: hclog.Info,
+			Color: hclog.AutoColor,
+		}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
 // Connect starts an MCP server and connects to it
@@ -82,7 +118,7 @@ func (c *Client) Connect(name string, config ServerConfig) error {
 	}
 
 	c.servers[name] = server
-	fmt.Printf("🔌 Connected to MCP server '%s' with %d tools\n", name, len(server.tools))
+	c.log.Info("connected to MCP server", "mcp_server", name, "tools", len(server.tools))
 
 	return nil
 }
@@ -127,10 +163,22 @@ func (c *Client) CallTool(serverName, toolName string, args map[string]interface
 		Arguments: args,
 	}
 
+	start := time.Now()
 	result, err := server.session.CallTool(c.ctx, params)
+	if c.metrics != nil {
+		c.metrics.MCPCallDuration.WithLabelValues(serverName, toolName).Observe(time.Since(start).Seconds())
+	}
 	if err != nil {
+		c.log.Error("MCP tool call failed", "mcp_server", serverName, "tool", toolName, "duration_ms", time.Since(start).Milliseconds(), "error", err)
+		if c.metrics != nil {
+			c.metrics.RecordToolCall(fmt.Sprintf("%s.%s", serverName, toolName), "error")
+		}
 		return "", fmt.Errorf("tool call failed: %w", err)
 	}
+	c.log.Info("MCP tool call completed", "mcp_server", serverName, "tool", toolName, "duration_ms", time.Since(start).Milliseconds())
+	if c.metrics != nil {
+		c.metrics.RecordToolCall(fmt.Sprintf("%s.%s", serverName, toolName), "ok")
+	}
 
 	// Extract text content from result
 	var output string
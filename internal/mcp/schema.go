@@ -0,0 +1,208 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// requiredSet returns schema's required property names as a lookup set.
+func requiredSet(schema *jsonschema.Schema) map[string]bool {
+	required := make(map[string]bool)
+	if schema == nil {
+		return required
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	return required
+}
+
+// validateAgainstSchema checks that args satisfies schema's required
+// properties and that every property present in args matches its declared
+// type. It returns a verbatim, human-readable error describing exactly what
+// is wrong, so an agent reading the error can self-correct.
+func validateAgainstSchema(args map[string]interface{}, schema *jsonschema.Schema) error {
+	if schema == nil {
+		return nil
+	}
+
+	for name := range requiredSet(schema) {
+		if _, ok := args[name]; !ok {
+			return fmt.Errorf("missing required property %q", name)
+		}
+	}
+
+	for name, value := range args {
+		prop, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		if err := validateType(name, value, prop.Type); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateType reports whether value matches a JSON Schema primitive type
+// name ("string", "number", "integer", "boolean", "array", "object").
+func validateType(name string, value interface{}, schemaType string) error {
+	if schemaType == "" {
+		return nil
+	}
+
+	ok := false
+	switch schemaType {
+	case "string":
+		_, ok = value.(string)
+	case "number":
+		switch value.(type) {
+		case float64, int, int64:
+			ok = true
+		}
+	case "integer":
+		switch v := value.(type) {
+		case int, int64:
+			ok = true
+		case float64:
+			ok = v == float64(int64(v))
+		}
+	case "boolean":
+		_, ok = value.(bool)
+	case "array":
+		_, ok = value.([]interface{})
+	case "object":
+		_, ok = value.(map[string]interface{})
+	default:
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("property %q must be of type %s, got %T", name, schemaType, value)
+	}
+	return nil
+}
+
+// parseKeyValuePairs parses input as a whitespace-separated list of
+// key=value pairs, e.g. `path=/tmp/foo recursive=true`. It returns ok=false
+// if any token lacks an "=", so the caller can fall through to the next
+// resolution strategy instead of misinterpreting free-form text.
+func parseKeyValuePairs(input string) (map[string]string, bool) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	pairs := make(map[string]string, len(fields))
+	for _, field := range fields {
+		key, value, found := strings.Cut(field, "=")
+		if !found || key == "" {
+			return nil, false
+		}
+		pairs[key] = value
+	}
+	return pairs, true
+}
+
+// coerceArgs converts string pairs into the types declared for each
+// property in schema (string/number/integer/boolean). Properties with no
+// schema entry, or no declared type, are passed through as strings.
+func coerceArgs(pairs map[string]string, schema *jsonschema.Schema) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(pairs))
+
+	for key, raw := range pairs {
+		var prop *jsonschema.Schema
+		if schema != nil {
+			prop = schema.Properties[key]
+		}
+		if prop == nil {
+			args[key] = raw
+			continue
+		}
+
+		coerced, err := coerceValue(key, raw, prop.Type)
+		if err != nil {
+			return nil, err
+		}
+		args[key] = coerced
+	}
+
+	return args, nil
+}
+
+func coerceValue(key, raw, schemaType string) (interface{}, error) {
+	switch schemaType {
+	case "number":
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("property %q must be a number, got %q", key, raw)
+		}
+		return v, nil
+	case "integer":
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("property %q must be an integer, got %q", key, raw)
+		}
+		return v, nil
+	case "boolean":
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("property %q must be a boolean, got %q", key, raw)
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
+
+// singleRequiredStringProperty returns the name of schema's sole property
+// when it is required and typed as a string, for the last-resort case where
+// raw input is bound directly to it.
+func singleRequiredStringProperty(schema *jsonschema.Schema) (string, bool) {
+	if schema == nil || len(schema.Required) != 1 {
+		return "", false
+	}
+
+	name := schema.Required[0]
+	prop, ok := schema.Properties[name]
+	if !ok || prop.Type != "string" {
+		return "", false
+	}
+	return name, true
+}
+
+// formatToolSignature renders a tool's schema as a compact function
+// signature list item, parameters sorted by name, e.g. "- server.readFile(
+// encoding?: string, path: string): reads a file", so the LLM sees parameter
+// names, types, and which are required.
+func formatToolSignature(serverName string, name, description string, schema *jsonschema.Schema) string {
+	required := requiredSet(schema)
+
+	var propNames []string
+	if schema != nil {
+		for name := range schema.Properties {
+			propNames = append(propNames, name)
+		}
+		sort.Strings(propNames)
+	}
+
+	params := make([]string, 0, len(propNames))
+	for _, propName := range propNames {
+		propType := schema.Properties[propName].Type
+		if propType == "" {
+			propType = "any"
+		}
+		marker := "?"
+		if required[propName] {
+			marker = ""
+		}
+		params = append(params, fmt.Sprintf("%s%s: %s", propName, marker, propType))
+	}
+
+	return fmt.Sprintf("- %s.%s(%s): %s", serverName, name, strings.Join(params, ", "), description)
+}
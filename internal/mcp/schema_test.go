@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+func stringSchema(required ...string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:     "object",
+		Required: required,
+		Properties: map[string]*jsonschema.Schema{
+			"path":      {Type: "string"},
+			"recursive": {Type: "boolean"},
+			"count":     {Type: "integer"},
+		},
+	}
+}
+
+func TestValidateAgainstSchemaMissingRequired(t *testing.T) {
+	err := validateAgainstSchema(map[string]interface{}{}, stringSchema("path"))
+	if err == nil {
+		t.Fatal("validateAgainstSchema() error = nil, want missing required property error")
+	}
+}
+
+func TestValidateAgainstSchemaWrongType(t *testing.T) {
+	args := map[string]interface{}{"path": 123}
+	if err := validateAgainstSchema(args, stringSchema("path")); err == nil {
+		t.Error("validateAgainstSchema() error = nil, want a type mismatch error")
+	}
+}
+
+func TestValidateAgainstSchemaOK(t *testing.T) {
+	args := map[string]interface{}{"path": "/tmp/foo", "recursive": true}
+	if err := validateAgainstSchema(args, stringSchema("path")); err != nil {
+		t.Errorf("validateAgainstSchema() error = %v, want nil", err)
+	}
+}
+
+func TestValidateAgainstSchemaNilSchemaAllowsAnything(t *testing.T) {
+	if err := validateAgainstSchema(map[string]interface{}{"anything": 1}, nil); err != nil {
+		t.Errorf("validateAgainstSchema() error = %v, want nil for a nil schema", err)
+	}
+}
+
+func TestCoerceArgs(t *testing.T) {
+	pairs := map[string]string{"path": "/tmp/foo", "recursive": "true", "count": "3"}
+	args, err := coerceArgs(pairs, stringSchema())
+	if err != nil {
+		t.Fatalf("coerceArgs() error = %v", err)
+	}
+
+	if args["path"] != "/tmp/foo" {
+		t.Errorf("path = %v, want /tmp/foo", args["path"])
+	}
+	if args["recursive"] != true {
+		t.Errorf("recursive = %v, want true", args["recursive"])
+	}
+	if args["count"] != int64(3) {
+		t.Errorf("count = %v, want int64(3)", args["count"])
+	}
+}
+
+func TestCoerceArgsInvalidInteger(t *testing.T) {
+	pairs := map[string]string{"count": "not-a-number"}
+	if _, err := coerceArgs(pairs, stringSchema()); err == nil {
+		t.Error("coerceArgs() error = nil, want an error for an invalid integer")
+	}
+}
+
+func TestParseKeyValuePairs(t *testing.T) {
+	pairs, ok := parseKeyValuePairs("path=/tmp/foo recursive=true")
+	if !ok {
+		t.Fatal("parseKeyValuePairs() ok = false, want true")
+	}
+	if pairs["path"] != "/tmp/foo" || pairs["recursive"] != "true" {
+		t.Errorf("pairs = %v", pairs)
+	}
+}
+
+func TestParseKeyValuePairsRejectsFreeformText(t *testing.T) {
+	if _, ok := parseKeyValuePairs("just some free-form text"); ok {
+		t.Error("parseKeyValuePairs() ok = true for text with no '=', want false")
+	}
+}
+
+func TestSingleRequiredStringProperty(t *testing.T) {
+	name, ok := singleRequiredStringProperty(stringSchema("path"))
+	if !ok || name != "path" {
+		t.Errorf("singleRequiredStringProperty() = (%q, %v), want (path, true)", name, ok)
+	}
+}
+
+func TestSingleRequiredStringPropertyMultipleRequired(t *testing.T) {
+	if _, ok := singleRequiredStringProperty(stringSchema("path", "count")); ok {
+		t.Error("singleRequiredStringProperty() ok = true with two required properties, want false")
+	}
+}
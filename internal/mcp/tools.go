@@ -1,14 +1,17 @@
 package mcp
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"Orkflow/internal/tools"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// MCPTool wraps an MCP tool to implement the Tool interface
+// MCPTool wraps an MCP tool to implement the Tool interface, validating
+// input against the tool's declared InputSchema before calling it.
 type MCPTool struct {
 	ServerName string
 	ToolDef    *mcp.Tool
@@ -23,15 +26,89 @@ func (t *MCPTool) Description() string {
 	return t.ToolDef.Description
 }
 
-func (t *MCPTool) Execute(input string) (string, error) {
-	// Parse input as simple key=value or just pass as single arg
-	args := map[string]interface{}{
-		"input": input,
+// JSONSchema returns the tool's declared InputSchema verbatim, re-encoded
+// as JSON, since MCP tools (unlike the built-in single-string tools)
+// already carry a real schema.
+func (t *MCPTool) JSONSchema() []byte {
+	data, err := json.Marshal(t.ToolDef.InputSchema)
+	if err != nil {
+		return []byte(`{"type":"object"}`)
 	}
+	return data
+}
 
+// Execute resolves input into arguments matching ToolDef's InputSchema, in
+// order of preference:
+//
+//  1. input is JSON matching the schema.
+//  2. input is "key=value key2=value2" pairs, coerced to each property's
+//     declared type.
+//  3. the schema has exactly one required string property, so the raw
+//     input is bound to it directly.
+//
+// A schema violation at any step is returned verbatim, so the calling agent
+// sees exactly what it got wrong and can self-correct.
+func (t *MCPTool) Execute(input string) (string, error) {
+	args, err := t.resolveArgs(input)
+	if err != nil {
+		return "", err
+	}
 	return t.Client.CallTool(t.ServerName, t.ToolDef.Name, args)
 }
 
+// toSchema converts a [mcp.Tool]'s InputSchema - declared as `any` so the SDK
+// can accept either a *jsonschema.Schema or a raw map decoded from the wire -
+// into the concrete *jsonschema.Schema this package validates and formats
+// against. A raw value is re-marshaled through JSON rather than trusted as
+// already being the right shape.
+func toSchema(inputSchema any) *jsonschema.Schema {
+	if inputSchema == nil {
+		return nil
+	}
+	if schema, ok := inputSchema.(*jsonschema.Schema); ok {
+		return schema
+	}
+
+	data, err := json.Marshal(inputSchema)
+	if err != nil {
+		return nil
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	return &schema
+}
+
+func (t *MCPTool) resolveArgs(input string) (map[string]interface{}, error) {
+	schema := toSchema(t.ToolDef.InputSchema)
+
+	var asJSON map[string]interface{}
+	if err := json.Unmarshal([]byte(input), &asJSON); err == nil {
+		if err := validateAgainstSchema(asJSON, schema); err != nil {
+			return nil, err
+		}
+		return asJSON, nil
+	}
+
+	if pairs, ok := parseKeyValuePairs(input); ok {
+		args, err := coerceArgs(pairs, schema)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateAgainstSchema(args, schema); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+
+	if prop, ok := singleRequiredStringProperty(schema); ok {
+		return map[string]interface{}{prop: input}, nil
+	}
+
+	return nil, fmt.Errorf("input %q does not match %s's input schema and could not be parsed as JSON or key=value pairs", input, t.Name())
+}
+
 // RegisterMCPTools registers all tools from an MCP server with the tool registry
 func RegisterMCPTools(client *Client, serverName string) error {
 	mcpTools, err := client.GetTools(serverName)
@@ -46,7 +123,7 @@ func RegisterMCPTools(client *Client, serverName string) error {
 			Client:     client,
 		}
 		tools.Register(tool)
-		fmt.Printf("  📦 Registered MCP tool: %s\n", tool.Name())
+		client.log.Info("registered MCP tool", "mcp_server", serverName, "tool", tool.Name())
 	}
 
 	return nil
@@ -62,7 +139,7 @@ func FormatMCPToolsForPrompt(client *Client) string {
 	result := "You have access to the following MCP tools:\n\n"
 	for serverName, serverTools := range allTools {
 		for _, tool := range serverTools {
-			result += fmt.Sprintf("- **%s.%s**: %s\n", serverName, tool.Name, tool.Description)
+			result += formatToolSignature(serverName, tool.Name, tool.Description, toSchema(tool.InputSchema)) + "\n"
 		}
 	}
 	result += "\nTo use an MCP tool, write your response in this format:\n"
@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+func TestToSchemaPassesThroughConcreteSchema(t *testing.T) {
+	want := &jsonschema.Schema{Type: "object", Required: []string{"path"}}
+
+	got := toSchema(want)
+	if got != want {
+		t.Errorf("toSchema() = %p, want the same *jsonschema.Schema back (%p)", got, want)
+	}
+}
+
+func TestToSchemaConvertsRawMap(t *testing.T) {
+	raw := map[string]any{
+		"type":     "object",
+		"required": []any{"path"},
+		"properties": map[string]any{
+			"path": map[string]any{"type": "string"},
+		},
+	}
+
+	schema := toSchema(raw)
+	if schema == nil {
+		t.Fatal("toSchema() = nil, want a converted schema")
+	}
+	if schema.Type != "object" || len(schema.Required) != 1 || schema.Required[0] != "path" {
+		t.Errorf("toSchema() = %+v, want type=object required=[path]", schema)
+	}
+	if schema.Properties["path"] == nil || schema.Properties["path"].Type != "string" {
+		t.Errorf("toSchema() properties = %+v, want path:string", schema.Properties)
+	}
+}
+
+func TestToSchemaNil(t *testing.T) {
+	if schema := toSchema(nil); schema != nil {
+		t.Errorf("toSchema(nil) = %+v, want nil", schema)
+	}
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	sessionsBucket       = []byte("sessions")         // id -> json Session
+	sessionsByTimeBucket = []byte("sessions_by_time") // updatedAt(8 bytes BE)+id -> id
+)
+
+// BoltStore persists sessions in a single BoltDB file, keyed by ID, with a
+// secondary index bucket keyed by UpdatedAt so List and Cleanup can walk
+// sessions in recency order without decoding every session's JSON just to
+// sort or age-check it.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB database at path. An
+// empty path defaults to ~/.orka/sessions.db.
+func NewBoltStore(path string) (*BoltStore, error) {
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, ".orka", "sessions.db")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sessions db: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(sessionsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(sessionsByTimeBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// timeIndexKey builds the sessions_by_time key for id: an 8-byte big-endian
+// UnixNano prefix (so bbolt's lexicographic key order is also time order)
+// followed by the session ID, to disambiguate same-instant collisions.
+func timeIndexKey(updatedAt time.Time, id string) []byte {
+	key := make([]byte, 8+len(id))
+	binary.BigEndian.PutUint64(key, uint64(updatedAt.UnixNano()))
+	copy(key[8:], id)
+	return key
+}
+
+func (s *BoltStore) Save(sess *Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		sessions := tx.Bucket(sessionsBucket)
+		byTime := tx.Bucket(sessionsByTimeBucket)
+
+		// A prior save of this ID indexed it under its old UpdatedAt; drop
+		// that entry before adding the new one, or it'd leave a stale
+		// duplicate in the time index.
+		if old := sessions.Get([]byte(sess.ID)); old != nil {
+			var prev Session
+			if err := json.Unmarshal(old, &prev); err == nil {
+				if err := byTime.Delete(timeIndexKey(prev.UpdatedAt, prev.ID)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := sessions.Put([]byte(sess.ID), data); err != nil {
+			return err
+		}
+		return byTime.Put(timeIndexKey(sess.UpdatedAt, sess.ID), []byte(sess.ID))
+	})
+}
+
+func (s *BoltStore) Load(id string) (*Session, error) {
+	var sess Session
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(sessionsBucket).Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("session not found: %s", id)
+		}
+		return json.Unmarshal(data, &sess)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+// List returns all sessions newest-first by walking the time index
+// backwards, rather than decoding and sorting every session as FileStore
+// does.
+func (s *BoltStore) List() ([]Session, error) {
+	var sessions []Session
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		sessionsB := tx.Bucket(sessionsBucket)
+		c := tx.Bucket(sessionsByTimeBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			data := sessionsB.Get(v)
+			if data == nil {
+				continue
+			}
+			var sess Session
+			if err := json.Unmarshal(data, &sess); err != nil {
+				return err
+			}
+			sessions = append(sessions, sess)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *BoltStore) Delete(id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		sessions := tx.Bucket(sessionsBucket)
+		data := sessions.Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+
+		var sess Session
+		if err := json.Unmarshal(data, &sess); err != nil {
+			return err
+		}
+		if err := sessions.Delete([]byte(id)); err != nil {
+			return err
+		}
+		return tx.Bucket(sessionsByTimeBucket).Delete(timeIndexKey(sess.UpdatedAt, sess.ID))
+	})
+}
+
+// Cleanup deletes sessions older than maxAge or beyond maxCount (keeping the
+// newest maxCount), walking the time index directly so it never decodes a
+// session's JSON just to discard it.
+func (s *BoltStore) Cleanup(maxAge time.Duration, maxCount int) error {
+	cutoff := time.Now().Add(-maxAge)
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		byTime := tx.Bucket(sessionsByTimeBucket)
+		sessions := tx.Bucket(sessionsBucket)
+
+		var keys, ids [][]byte
+		c := byTime.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			keys = append(keys, append([]byte(nil), k...))
+			ids = append(ids, append([]byte(nil), v...))
+		}
+
+		for i, k := range keys {
+			updatedAtNanos := int64(binary.BigEndian.Uint64(k[:8]))
+			expired := time.Unix(0, updatedAtNanos).Before(cutoff)
+			overLimit := i >= maxCount
+			if !expired && !overLimit {
+				continue
+			}
+			if err := byTime.Delete(k); err != nil {
+				return err
+			}
+			if err := sessions.Delete(ids[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// mainBranchID is the branch every Session starts on, and the branch older
+// sessions (saved before branching existed) are backfilled onto.
+const mainBranchID = "main"
+
+// Branch is a named head pointer into a Session's message tree. ForkFrom
+// creates additional branches that share the same underlying Messages slice
+// as "main" but diverge from a chosen ancestor message.
+type Branch struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	HeadID    string    `json:"head_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ensureBranches lazily initializes Branches/ActiveBranch and, for sessions
+// saved before branching existed, backfills ID/ParentID/BranchID onto every
+// message in its original append order so GetHistory keeps linearizing
+// correctly. It is idempotent and safe to call on every access.
+func (s *Session) ensureBranches() {
+	if s.Branches == nil {
+		s.Branches = make(map[string]*Branch)
+	}
+	if _, ok := s.Branches[mainBranchID]; !ok {
+		s.Branches[mainBranchID] = &Branch{ID: mainBranchID, CreatedAt: s.CreatedAt}
+	}
+	if s.ActiveBranch == "" {
+		s.ActiveBranch = mainBranchID
+	}
+
+	main := s.Branches[mainBranchID]
+	for i := range s.Messages {
+		msg := &s.Messages[i]
+		if msg.ID != "" {
+			continue
+		}
+		msg.ID = GenerateID()
+		msg.BranchID = mainBranchID
+		msg.ParentID = main.HeadID
+		main.HeadID = msg.ID
+	}
+}
+
+// messageByID returns the message with the given ID, if any exists on any
+// branch.
+func (s *Session) messageByID(id string) (*Message, bool) {
+	for i := range s.Messages {
+		if s.Messages[i].ID == id {
+			return &s.Messages[i], true
+		}
+	}
+	return nil, false
+}
+
+// linearize walks branchID's head back to the root via ParentID links and
+// returns the result in chronological order.
+func (s *Session) linearize(branchID string) []Message {
+	branch, ok := s.Branches[branchID]
+	if !ok {
+		return nil
+	}
+
+	byID := make(map[string]*Message, len(s.Messages))
+	for i := range s.Messages {
+		byID[s.Messages[i].ID] = &s.Messages[i]
+	}
+
+	var chain []Message
+	for id := branch.HeadID; id != ""; {
+		msg, ok := byID[id]
+		if !ok {
+			break
+		}
+		chain = append(chain, *msg)
+		id = msg.ParentID
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// ForkFrom creates a new branch whose lineage is msgID's ancestry plus
+// msgID itself, makes it the session's active branch, and returns its ID.
+// Every existing branch, including msgID's own, is left untouched - later
+// AddMessage calls extend the new branch without disturbing them. Pass ""
+// to fork a new, empty branch.
+func (s *Session) ForkFrom(msgID string) (string, error) {
+	s.ensureBranches()
+
+	if msgID != "" {
+		if _, ok := s.messageByID(msgID); !ok {
+			return "", fmt.Errorf("message not found: %s", msgID)
+		}
+	}
+
+	branch := &Branch{
+		ID:        GenerateID(),
+		HeadID:    msgID,
+		CreatedAt: time.Now(),
+	}
+	s.Branches[branch.ID] = branch
+	s.ActiveBranch = branch.ID
+	s.UpdatedAt = time.Now()
+
+	return branch.ID, nil
+}
+
+// Checkout switches the session's active branch, so subsequent AddMessage
+// and GetHistory calls operate on branchID's lineage.
+func (s *Session) Checkout(branchID string) error {
+	s.ensureBranches()
+
+	if _, ok := s.Branches[branchID]; !ok {
+		return fmt.Errorf("branch not found: %s", branchID)
+	}
+	s.ActiveBranch = branchID
+	return nil
+}
+
+// RenameBranch sets the human-readable name `orka branches` displays for
+// branchID. Branch IDs, not names, are what ForkFrom and Checkout use.
+func (s *Session) RenameBranch(branchID, name string) error {
+	s.ensureBranches()
+
+	branch, ok := s.Branches[branchID]
+	if !ok {
+		return fmt.Errorf("branch not found: %s", branchID)
+	}
+	branch.Name = name
+	return nil
+}
+
+// Message returns the message with the given ID, if any exists on any
+// branch of the session.
+func (s *Session) Message(id string) (Message, bool) {
+	s.ensureBranches()
+	msg, ok := s.messageByID(id)
+	if !ok {
+		return Message{}, false
+	}
+	return *msg, true
+}
+
+// BranchByName looks up a branch by its display name (set via RenameBranch
+// or the --branch flag at fork time). Names aren't required to be unique;
+// the oldest matching branch wins.
+func (s *Session) BranchByName(name string) (*Branch, bool) {
+	for _, b := range s.ListBranches() {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// ListBranches returns every branch in the session, oldest first.
+func (s *Session) ListBranches() []*Branch {
+	s.ensureBranches()
+
+	branches := make([]*Branch, 0, len(s.Branches))
+	for _, b := range s.Branches {
+		branches = append(branches, b)
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].CreatedAt.Before(branches[j].CreatedAt)
+	})
+	return branches
+}
+
+// BranchHistory returns the linearized messages for branchID, which need
+// not be the active branch, for display or diffing (e.g. `orka branches`).
+func (s *Session) BranchHistory(branchID string) []Message {
+	s.ensureBranches()
+	return s.linearize(branchID)
+}
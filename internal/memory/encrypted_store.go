@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// encryptedEnvelopeMarker flags a Session produced by Encrypted so Load/List
+// can tell an encrypted envelope apart from a plaintext one written by some
+// other store.
+const encryptedEnvelopeMarker = "__orka_encrypted_session_v1__"
+
+// Encrypted wraps a SessionStore, encrypting each session's JSON with
+// AES-256-GCM before handing it to the underlying backend, and decrypting on
+// the way back out. Sessions can contain sensitive LLM prompts and
+// responses, so this lets any backend (file, bolt, remote) be used without
+// storing that content in the clear. The wrapped session is itself a Session
+// (ID and UpdatedAt preserved, content replaced by a single opaque message),
+// so the inner store's own indexing - e.g. BoltStore's by-time bucket -
+// still works.
+type Encrypted struct {
+	inner SessionStore
+	key   [32]byte
+}
+
+// NewEncrypted wraps inner with AES-GCM encryption keyed by the SHA-256
+// digest of keyMaterial, so any non-empty passphrase or random secret works
+// as key material regardless of its length.
+func NewEncrypted(inner SessionStore, keyMaterial string) (*Encrypted, error) {
+	if keyMaterial == "" {
+		return nil, fmt.Errorf("memory: encryption key material is empty")
+	}
+	return &Encrypted{inner: inner, key: sha256.Sum256([]byte(keyMaterial))}, nil
+}
+
+// NewEncryptedFromEnv wraps inner using the key material in the named
+// environment variable.
+func NewEncryptedFromEnv(inner SessionStore, envVar string) (*Encrypted, error) {
+	keyMaterial := os.Getenv(envVar)
+	if keyMaterial == "" {
+		return nil, fmt.Errorf("memory: environment variable %s is not set", envVar)
+	}
+	return NewEncrypted(inner, keyMaterial)
+}
+
+// NewEncryptedFromFile wraps inner using the key material read from path.
+func NewEncryptedFromFile(inner SessionStore, path string) (*Encrypted, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("memory: reading encryption key file: %w", err)
+	}
+	return NewEncrypted(inner, strings.TrimSpace(string(data)))
+}
+
+func (e *Encrypted) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(e.key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *Encrypted) encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := e.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (e *Encrypted) decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := e.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("memory: encrypted session data is too short")
+	}
+	nonce, data := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+func (e *Encrypted) Save(s *Session) error {
+	plaintext, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := e.encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("memory: encrypting session %s: %w", s.ID, err)
+	}
+
+	envelope := &Session{
+		ID:        s.ID,
+		Workflow:  encryptedEnvelopeMarker,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		Messages: []Message{{
+			Content: base64.StdEncoding.EncodeToString(ciphertext),
+		}},
+	}
+	return e.inner.Save(envelope)
+}
+
+func (e *Encrypted) decryptEnvelope(envelope *Session) (*Session, error) {
+	if envelope.Workflow != encryptedEnvelopeMarker || len(envelope.Messages) != 1 {
+		return nil, fmt.Errorf("memory: session %s is not an encrypted envelope", envelope.ID)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Messages[0].Content)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := e.decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("memory: decrypting session %s: %w", envelope.ID, err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(plaintext, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (e *Encrypted) Load(id string) (*Session, error) {
+	envelope, err := e.inner.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	return e.decryptEnvelope(envelope)
+}
+
+func (e *Encrypted) List() ([]Session, error) {
+	envelopes, err := e.inner.List()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(envelopes))
+	for i := range envelopes {
+		s, err := e.decryptEnvelope(&envelopes[i])
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+	return sessions, nil
+}
+
+func (e *Encrypted) Delete(id string) error {
+	return e.inner.Delete(id)
+}
+
+func (e *Encrypted) Cleanup(maxAge time.Duration, maxCount int) error {
+	return e.inner.Cleanup(maxAge, maxCount)
+}
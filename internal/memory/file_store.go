@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FileStore persists each session as its own JSON file under its directory,
+// the original (and still default) backend. Listing and cleanup scan the
+// whole directory, which is fine for the handful-to-low-thousands of
+// sessions a single-user CLI accumulates but doesn't scale much past that -
+// see BoltStore for an indexed alternative.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a filesystem-backed SessionStore. An empty dir
+// defaults to GetSessionsDir() (~/.orka/sessions).
+func NewFileStore(dir string) *FileStore {
+	if dir == "" {
+		dir = GetSessionsDir()
+	}
+	return &FileStore{dir: dir}
+}
+
+func (f *FileStore) path(id string) string {
+	return filepath.Join(f.dir, id+".json")
+}
+
+func (f *FileStore) Save(s *Session) error {
+	if err := os.MkdirAll(f.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path(s.ID), data, 0644)
+}
+
+func (f *FileStore) Load(id string) (*Session, error) {
+	data, err := os.ReadFile(f.path(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (f *FileStore) List() ([]Session, error) {
+	files, err := os.ReadDir(f.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Session{}, nil
+		}
+		return nil, err
+	}
+
+	var sessions []Session
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		id := file.Name()[:len(file.Name())-len(".json")]
+		s, err := f.Load(id)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+
+	return sessions, nil
+}
+
+func (f *FileStore) Delete(id string) error {
+	return os.Remove(f.path(id))
+}
+
+func (f *FileStore) Cleanup(maxAge time.Duration, maxCount int) error {
+	sessions, err := f.List()
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for i, s := range sessions {
+		if s.UpdatedAt.Before(cutoff) || i >= maxCount {
+			if err := f.Delete(s.ID); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
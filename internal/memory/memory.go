@@ -3,11 +3,9 @@ package memory
 import (
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 )
 
@@ -17,7 +15,14 @@ const (
 	SessionsFolder = ".orka/sessions"
 )
 
+// Message is one node in a Session's conversation tree. ParentID links it to
+// the message it followed on its branch; BranchID is the branch it was added
+// on. A Session's Messages slice holds every branch's messages together -
+// see branch.go for how a single branch is linearized out of it.
 type Message struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	BranchID  string    `json:"branch_id"`
 	AgentID   string    `json:"agent_id"`
 	Role      string    `json:"role"`
 	Content   string    `json:"content"`
@@ -25,14 +30,16 @@ type Message struct {
 }
 
 type Session struct {
-	ID        string    `json:"id"`
-	Workflow  string    `json:"workflow"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Messages  []Message `json:"messages"`
+	ID           string             `json:"id"`
+	Workflow     string             `json:"workflow"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	Messages     []Message          `json:"messages"`
+	Branches     map[string]*Branch `json:"branches,omitempty"`
+	ActiveBranch string             `json:"active_branch,omitempty"`
 }
 
-// GetSessionsDir returns the path to sessions directory
+// GetSessionsDir returns the path to the default FileStore sessions directory
 func GetSessionsDir() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, SessionsFolder)
@@ -56,93 +63,58 @@ func NewSession(workflow string) *Session {
 	}
 }
 
-// AddMessage appends a message to the session
+// AddMessage appends a message onto the active branch
 func (s *Session) AddMessage(agentID, role, content string) {
-	s.Messages = append(s.Messages, Message{
+	s.ensureBranches()
+
+	branch := s.Branches[s.ActiveBranch]
+	msg := Message{
+		ID:        GenerateID(),
+		ParentID:  branch.HeadID,
+		BranchID:  s.ActiveBranch,
 		AgentID:   agentID,
 		Role:      role,
 		Content:   content,
 		Timestamp: time.Now(),
-	})
+	}
+
+	s.Messages = append(s.Messages, msg)
+	branch.HeadID = msg.ID
 	s.UpdatedAt = time.Now()
 }
 
-// GetHistory returns formatted history for context
+// GetHistory returns the active branch's messages, formatted for context.
+// Other branches exist in the same session but are not included here - see
+// Checkout.
 func (s *Session) GetHistory() string {
-	if len(s.Messages) == 0 {
+	s.ensureBranches()
+	messages := s.linearize(s.ActiveBranch)
+	if len(messages) == 0 {
 		return ""
 	}
 
 	var result string
 	result = "=== Previous Session Context ===\n\n"
-	for _, msg := range s.Messages {
+	for _, msg := range messages {
 		result += fmt.Sprintf("[%s] %s:\n%s\n\n", msg.AgentID, msg.Role, msg.Content)
 	}
 	return result
 }
 
-// Save persists the session to disk
+// Save persists the session through the active SessionStore (see SetStore).
 func (s *Session) Save() error {
-	dir := GetSessionsDir()
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
-	data, err := json.MarshalIndent(s, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	path := filepath.Join(dir, s.ID+".json")
-	return os.WriteFile(path, data, 0644)
+	return activeStore.Save(s)
 }
 
-// LoadSession loads a session by ID
+// LoadSession loads a session by ID through the active SessionStore.
 func LoadSession(id string) (*Session, error) {
-	path := filepath.Join(GetSessionsDir(), id+".json")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	var session Session
-	if err := json.Unmarshal(data, &session); err != nil {
-		return nil, err
-	}
-	return &session, nil
+	return activeStore.Load(id)
 }
 
-// ListSessions returns all session IDs sorted by update time
+// ListSessions returns all sessions sorted newest-first through the active
+// SessionStore.
 func ListSessions() ([]Session, error) {
-	dir := GetSessionsDir()
-	files, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []Session{}, nil
-		}
-		return nil, err
-	}
-
-	var sessions []Session
-	for _, f := range files {
-		if filepath.Ext(f.Name()) != ".json" {
-			continue
-		}
-
-		id := f.Name()[:len(f.Name())-5]
-		session, err := LoadSession(id)
-		if err != nil {
-			continue
-		}
-		sessions = append(sessions, *session)
-	}
-
-	// Sort by updated_at descending
-	sort.Slice(sessions, func(i, j int) bool {
-		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
-	})
-
-	return sessions, nil
+	return activeStore.List()
 }
 
 // GetLatestSession returns the most recently updated session
@@ -154,40 +126,13 @@ func GetLatestSession() (*Session, error) {
 	return &sessions[0], nil
 }
 
-// CleanupOldSessions removes expired and excess sessions
+// CleanupOldSessions removes sessions older than ExpiryDays or beyond
+// MaxSessions (keeping the newest) through the active SessionStore.
 func CleanupOldSessions() error {
-	sessions, err := ListSessions()
-	if err != nil {
-		return err
-	}
-
-	cutoff := time.Now().AddDate(0, 0, -ExpiryDays)
-	dir := GetSessionsDir()
-
-	for i, s := range sessions {
-		shouldDelete := false
-
-		// Delete if expired
-		if s.UpdatedAt.Before(cutoff) {
-			shouldDelete = true
-		}
-
-		// Delete if over max limit (keep newest)
-		if i >= MaxSessions {
-			shouldDelete = true
-		}
-
-		if shouldDelete {
-			path := filepath.Join(dir, s.ID+".json")
-			os.Remove(path)
-		}
-	}
-
-	return nil
+	return activeStore.Cleanup(time.Duration(ExpiryDays)*24*time.Hour, MaxSessions)
 }
 
-// DeleteSession removes a session by ID
+// DeleteSession removes a session by ID through the active SessionStore.
 func DeleteSession(id string) error {
-	path := filepath.Join(GetSessionsDir(), id+".json")
-	return os.Remove(path)
+	return activeStore.Delete(id)
 }
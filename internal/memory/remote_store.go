@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	remoteSessionKeyPrefix  = "orka:session:"
+	remoteSessionsByTimeKey = "orka:sessions:by_time"
+)
+
+// RemoteStore persists sessions in Redis, so multiple orka processes across
+// machines can share session state instead of each keeping its own local
+// copy. Sessions are plain JSON values keyed by ID, with a sorted set
+// (scored by UpdatedAt) as a secondary index for List and Cleanup.
+type RemoteStore struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+// NewRemoteStore connects to a Redis instance at addr (host:port).
+func NewRemoteStore(addr string) (*RemoteStore, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to remote session store: %w", err)
+	}
+
+	return &RemoteStore{client: client, ctx: ctx}, nil
+}
+
+func remoteSessionKey(id string) string {
+	return remoteSessionKeyPrefix + id
+}
+
+func (r *RemoteStore) Save(s *Session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.Set(r.ctx, remoteSessionKey(s.ID), data, 0).Err(); err != nil {
+		return err
+	}
+	return r.client.ZAdd(r.ctx, remoteSessionsByTimeKey, redis.Z{
+		Score:  float64(s.UpdatedAt.Unix()),
+		Member: s.ID,
+	}).Err()
+}
+
+func (r *RemoteStore) Load(id string) (*Session, error) {
+	data, err := r.client.Get(r.ctx, remoteSessionKey(id)).Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("session not found: %s: %w", id, err)
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// List returns all sessions newest-first via the sorted-set index, rather
+// than scanning every key.
+func (r *RemoteStore) List() ([]Session, error) {
+	ids, err := r.client.ZRevRange(r.ctx, remoteSessionsByTimeKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(ids))
+	for _, id := range ids {
+		s, err := r.Load(id)
+		if err != nil {
+			// The index and the value it points at can drift apart (e.g. a
+			// concurrent Delete from another process); skip rather than fail
+			// the whole list.
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+	return sessions, nil
+}
+
+func (r *RemoteStore) Delete(id string) error {
+	if err := r.client.Del(r.ctx, remoteSessionKey(id)).Err(); err != nil {
+		return err
+	}
+	return r.client.ZRem(r.ctx, remoteSessionsByTimeKey, id).Err()
+}
+
+// Cleanup deletes sessions older than maxAge or beyond maxCount (keeping the
+// newest), using ZRangeByScore/ZRevRange so it never fetches a session's
+// value just to decide whether to discard it.
+func (r *RemoteStore) Cleanup(maxAge time.Duration, maxCount int) error {
+	cutoff := float64(time.Now().Add(-maxAge).Unix())
+
+	expired, err := r.client.ZRangeByScore(r.ctx, remoteSessionsByTimeKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%f", cutoff),
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	overLimit, err := r.client.ZRevRange(r.ctx, remoteSessionsByTimeKey, int64(maxCount), -1).Result()
+	if err != nil {
+		return err
+	}
+
+	toDelete := make(map[string]bool, len(expired)+len(overLimit))
+	for _, id := range expired {
+		toDelete[id] = true
+	}
+	for _, id := range overLimit {
+		toDelete[id] = true
+	}
+
+	for id := range toDelete {
+		if err := r.Delete(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying Redis connection.
+func (r *RemoteStore) Close() error {
+	return r.client.Close()
+}
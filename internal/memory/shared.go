@@ -1,38 +1,82 @@
 package memory
 
 import (
+	"context"
 	"fmt"
+	"path"
 	"sync"
 	"time"
 )
 
+// defaultSubscriberBuffer is how many pending events a Subscribe channel
+// holds before Set starts dropping the oldest to make room.
+const defaultSubscriberBuffer = 16
+
+// Event is a notification delivered to subscribers when Set (or one of the
+// compare-and-swap helpers) stores a new value under a matching key.
+type Event struct {
+	Key       string
+	Value     interface{}
+	Timestamp time.Time
+}
+
+// CancelFunc unsubscribes a Subscribe call, closing its event channel.
+type CancelFunc func()
+
+// Option configures a SharedMemory at construction time.
+type Option func(*SharedMemory)
+
+// WithSubscriberBuffer sets the per-subscriber channel buffer size. Once
+// full, Set drops the oldest buffered event for that subscriber rather than
+// blocking. Defaults to defaultSubscriberBuffer.
+func WithSubscriberBuffer(n int) Option {
+	return func(sm *SharedMemory) {
+		sm.subscriberBuffer = n
+	}
+}
+
+type subscription struct {
+	pattern string
+	ch      chan Event
+}
+
 // SharedMemory is a thread-safe key-value store for inter-agent communication
-// within a workflow session. Agents can publish data under keys and subscribe
-// to data from other agents.
+// within a workflow session. Agents can publish data under keys, read it
+// back, and subscribe to glob patterns over keys for event-driven
+// coordination (barriers, leader election, fan-in/fan-out between agents).
 type SharedMemory struct {
 	mu        sync.RWMutex
 	data      map[string]interface{}
 	sessionID string
-	cond      *sync.Cond
+
+	subMu            sync.Mutex
+	subs             map[int]*subscription
+	nextSubID        int
+	subscriberBuffer int
 }
 
-// NewSharedMemory creates a new SharedMemory instance for a session
-func NewSharedMemory(sessionID string) *SharedMemory {
+// NewSharedMemory creates a new SharedMemory instance for a session.
+func NewSharedMemory(sessionID string, opts ...Option) *SharedMemory {
 	sm := &SharedMemory{
-		data:      make(map[string]interface{}),
-		sessionID: sessionID,
+		data:             make(map[string]interface{}),
+		sessionID:        sessionID,
+		subs:             make(map[int]*subscription),
+		subscriberBuffer: defaultSubscriberBuffer,
+	}
+	for _, opt := range opts {
+		opt(sm)
 	}
-	sm.cond = sync.NewCond(&sm.mu)
 	return sm
 }
 
-// Set stores a value under a key. This is thread-safe and will notify
-// any goroutines waiting on this key.
+// Set stores a value under a key and notifies any subscribers whose pattern
+// matches it.
 func (sm *SharedMemory) Set(key string, value interface{}) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
 	sm.data[key] = value
-	sm.cond.Broadcast() // Wake up all waiters
+	sm.mu.Unlock()
+
+	sm.publish(Event{Key: key, Value: value, Timestamp: time.Now()})
 }
 
 // Get retrieves a value by key. Returns the value and true if found,
@@ -57,44 +101,153 @@ func (sm *SharedMemory) GetString(key string) string {
 	return fmt.Sprintf("%v", val)
 }
 
-// WaitFor blocks until a key is available or timeout is reached.
-// Returns the value and nil error if found, or nil and error if timeout.
-func (sm *SharedMemory) WaitFor(key string, timeout time.Duration) (interface{}, error) {
-	deadline := time.Now().Add(timeout)
+// SetIfAbsent sets key to value only if key is not already present, returning
+// true if it did so. Useful as a first-writer-wins primitive, e.g. leader
+// election among parallel agents racing to claim a role.
+func (sm *SharedMemory) SetIfAbsent(key string, value interface{}) bool {
+	sm.mu.Lock()
+	if _, exists := sm.data[key]; exists {
+		sm.mu.Unlock()
+		return false
+	}
+	sm.data[key] = value
+	sm.mu.Unlock()
+
+	sm.publish(Event{Key: key, Value: value, Timestamp: time.Now()})
+	return true
+}
+
+// CompareAndSwap sets key to newValue only if its current value equals
+// oldValue, returning true if the swap happened. A missing key only matches
+// when oldValue is nil, so CompareAndSwap(key, nil, v) behaves like
+// SetIfAbsent.
+func (sm *SharedMemory) CompareAndSwap(key string, oldValue, newValue interface{}) bool {
+	sm.mu.Lock()
+	current, ok := sm.data[key]
+	if ok {
+		if current != oldValue {
+			sm.mu.Unlock()
+			return false
+		}
+	} else if oldValue != nil {
+		sm.mu.Unlock()
+		return false
+	}
+	sm.data[key] = newValue
+	sm.mu.Unlock()
+
+	sm.publish(Event{Key: key, Value: newValue, Timestamp: time.Now()})
+	return true
+}
 
+// Delete removes key, if present. It does not publish an event: subscribers
+// observe Set and the compare-and-swap helpers, not removal.
+func (sm *SharedMemory) Delete(key string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+	delete(sm.data, key)
+}
 
-	for {
-		// Check if key exists
-		if val, ok := sm.data[key]; ok {
-			return val, nil
-		}
+// Subscribe registers interest in keys matching pattern (a path.Match glob,
+// e.g. "agent.*.result" or "review.*") and returns a channel of matching
+// events plus a CancelFunc that unsubscribes and closes the channel. Callers
+// must call the CancelFunc once they're done reading to release the
+// subscription; it is safe to call more than once.
+func (sm *SharedMemory) Subscribe(pattern string) (<-chan Event, CancelFunc) {
+	buf := sm.subscriberBuffer
+	if buf <= 0 {
+		buf = defaultSubscriberBuffer
+	}
 
-		// Check timeout
-		remaining := time.Until(deadline)
-		if remaining <= 0 {
-			return nil, fmt.Errorf("timeout waiting for key '%s' after %v", key, timeout)
-		}
+	sub := &subscription{
+		pattern: pattern,
+		ch:      make(chan Event, buf),
+	}
+
+	sm.subMu.Lock()
+	id := sm.nextSubID
+	sm.nextSubID++
+	sm.subs[id] = sub
+	sm.subMu.Unlock()
 
-		// Wait with timeout using a goroutine
-		done := make(chan struct{})
-		go func() {
-			time.Sleep(remaining)
-			sm.cond.Broadcast()
-			close(done)
-		}()
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			sm.subMu.Lock()
+			delete(sm.subs, id)
+			sm.subMu.Unlock()
+			close(sub.ch)
+		})
+	}
+
+	return sub.ch, cancel
+}
 
-		// Wait for signal
-		sm.cond.Wait()
+// publish fans ev out to every subscriber whose pattern matches its key. The
+// send is non-blocking: a subscriber with a full buffer has its oldest
+// pending event dropped to make room, so a slow or stalled reader never
+// blocks Set.
+func (sm *SharedMemory) publish(ev Event) {
+	sm.subMu.Lock()
+	defer sm.subMu.Unlock()
 
-		// Clean up timer goroutine by checking if done was already closed
+	for _, sub := range sm.subs {
+		if matched, err := path.Match(sub.pattern, ev.Key); err != nil || !matched {
+			continue
+		}
 		select {
-		case <-done:
-			// Timer fired, will check again and likely timeout
+		case sub.ch <- ev:
 		default:
-			// Signal came from Set(), continue to check
+			select {
+			case <-sub.ch:
+			default:
+			}
+			select {
+			case sub.ch <- ev:
+			default:
+			}
+		}
+	}
+}
+
+// WaitFor blocks until a key is available or timeout is reached.
+// Returns the value and nil error if found, or nil and error if timeout.
+func (sm *SharedMemory) WaitFor(key string, timeout time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	val, err := sm.WaitForCtx(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("timeout waiting for key '%s' after %v", key, timeout)
+	}
+	return val, nil
+}
+
+// WaitForCtx blocks until key is set or ctx is done, whichever comes first.
+// Unlike the old cond-broadcast implementation, cancelling ctx always
+// returns promptly and never leaves a goroutine behind.
+func (sm *SharedMemory) WaitForCtx(ctx context.Context, key string) (interface{}, error) {
+	if val, ok := sm.Get(key); ok {
+		return val, nil
+	}
+
+	events, cancel := sm.Subscribe(key)
+	defer cancel()
+
+	// A Set may have landed between the Get above and Subscribe taking
+	// effect; check once more now that we're listening so it isn't missed.
+	if val, ok := sm.Get(key); ok {
+		return val, nil
+	}
+
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			return nil, fmt.Errorf("subscription for key '%s' closed", key)
 		}
+		return ev.Value, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 }
 
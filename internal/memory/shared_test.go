@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -150,3 +151,112 @@ func TestSharedMemory_Clear(t *testing.T) {
 		t.Error("Expected no keys after clear")
 	}
 }
+
+func TestSharedMemory_Subscribe(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	events, cancel := sm.Subscribe("agent.*.result")
+	defer cancel()
+
+	sm.Set("agent.reviewer.result", "approved")
+	sm.Set("other.key", "ignored")
+
+	select {
+	case ev := <-events:
+		if ev.Key != "agent.reviewer.result" || ev.Value != "approved" {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected matching event, got none")
+	}
+
+	select {
+	case ev := <-events:
+		t.Errorf("expected no further events, got %+v", ev)
+	default:
+	}
+}
+
+func TestSharedMemory_Subscribe_Cancel(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	events, cancel := sm.Subscribe("key")
+	cancel()
+	cancel() // must be safe to call twice
+
+	if _, ok := <-events; ok {
+		t.Error("expected channel to be closed after cancel")
+	}
+}
+
+func TestSharedMemory_Subscribe_DropsOldest(t *testing.T) {
+	sm := NewSharedMemory("test-session", WithSubscriberBuffer(1))
+
+	events, cancel := sm.Subscribe("key")
+	defer cancel()
+
+	sm.Set("key", "first")
+	sm.Set("key", "second")
+
+	ev := <-events
+	if ev.Value != "second" {
+		t.Errorf("expected buffer to retain only the newest event, got %+v", ev)
+	}
+}
+
+func TestSharedMemory_WaitForCtx_Cancel(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := sm.WaitForCtx(ctx, "never")
+	if err == nil {
+		t.Error("expected error when context is cancelled")
+	}
+}
+
+func TestSharedMemory_SetIfAbsent(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	if !sm.SetIfAbsent("leader", "agent-1") {
+		t.Error("expected first SetIfAbsent to succeed")
+	}
+	if sm.SetIfAbsent("leader", "agent-2") {
+		t.Error("expected second SetIfAbsent to fail")
+	}
+	if val := sm.GetString("leader"); val != "agent-1" {
+		t.Errorf("expected leader to remain 'agent-1', got %q", val)
+	}
+}
+
+func TestSharedMemory_CompareAndSwap(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	if !sm.CompareAndSwap("state", nil, "running") {
+		t.Error("expected CAS against missing key with nil oldValue to succeed")
+	}
+	if sm.CompareAndSwap("state", "stopped", "done") {
+		t.Error("expected CAS with wrong oldValue to fail")
+	}
+	if !sm.CompareAndSwap("state", "running", "done") {
+		t.Error("expected CAS with correct oldValue to succeed")
+	}
+	if val := sm.GetString("state"); val != "done" {
+		t.Errorf("expected state to be 'done', got %q", val)
+	}
+}
+
+func TestSharedMemory_Delete(t *testing.T) {
+	sm := NewSharedMemory("test-session")
+
+	sm.Set("key", "value")
+	sm.Delete("key")
+
+	if _, ok := sm.Get("key"); ok {
+		t.Error("expected key to be gone after Delete")
+	}
+
+	// Deleting a missing key is a no-op, not an error.
+	sm.Delete("missing")
+}
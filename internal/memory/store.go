@@ -0,0 +1,29 @@
+package memory
+
+import "time"
+
+// SessionStore persists and retrieves Sessions. FileStore (one JSON file per
+// session) is the default and still what a fresh install uses; BoltStore and
+// RemoteStore trade that simplicity for an indexed List/Cleanup that doesn't
+// require reading every session on disk, and RemoteStore additionally lets
+// multiple orka processes share session state instead of each keeping its
+// own local copy.
+type SessionStore interface {
+	Save(s *Session) error
+	Load(id string) (*Session, error)
+	List() ([]Session, error)
+	Delete(id string) error
+	Cleanup(maxAge time.Duration, maxCount int) error
+}
+
+// activeStore backs the package-level Session.Save/LoadSession/ListSessions/
+// DeleteSession/CleanupOldSessions helpers. It defaults to a FileStore so
+// existing callers keep working unchanged until SetStore is called.
+var activeStore SessionStore = NewFileStore("")
+
+// SetStore replaces the package-level store used by Session.Save and the
+// top-level session helpers. Call it once at startup (e.g. from the CLI's
+// --session-store flag) before any session is saved or loaded.
+func SetStore(s SessionStore) {
+	activeStore = s
+}
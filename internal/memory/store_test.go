@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFileStore_SaveLoadListDelete(t *testing.T) {
+	dir, err := os.MkdirTemp("", "orka_test_sessions")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(dir)
+
+	s := NewSession("test.yaml")
+	s.AddMessage("agent-1", "user", "hello")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := store.Load(s.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.ID != s.ID || len(loaded.Messages) != 1 {
+		t.Errorf("unexpected loaded session: %+v", loaded)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Errorf("expected 1 session, got %d", len(sessions))
+	}
+
+	if err := store.Delete(s.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Load(s.ID); err == nil {
+		t.Error("expected error loading deleted session")
+	}
+}
+
+func TestFileStore_Cleanup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "orka_test_sessions_cleanup")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileStore(dir)
+
+	old := NewSession("test.yaml")
+	old.UpdatedAt = time.Now().Add(-48 * time.Hour)
+	if err := store.Save(old); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	recent := NewSession("test.yaml")
+	if err := store.Save(recent); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if err := store.Cleanup(24*time.Hour, 50); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+
+	sessions, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != recent.ID {
+		t.Errorf("expected only the recent session to survive cleanup, got %+v", sessions)
+	}
+}
+
+func TestEncrypted_RoundTrip(t *testing.T) {
+	dir, err := os.MkdirTemp("", "orka_test_sessions_encrypted")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	inner := NewFileStore(dir)
+	store, err := NewEncrypted(inner, "test-passphrase")
+	if err != nil {
+		t.Fatalf("NewEncrypted failed: %v", err)
+	}
+
+	s := NewSession("test.yaml")
+	s.AddMessage("agent-1", "user", "this should not be stored in plaintext")
+	if err := store.Save(s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The inner store should only ever see ciphertext, never the real content.
+	rawEnvelope, err := inner.Load(s.ID)
+	if err != nil {
+		t.Fatalf("inner.Load failed: %v", err)
+	}
+	for _, msg := range rawEnvelope.Messages {
+		if msg.Content == "this should not be stored in plaintext" {
+			t.Error("expected inner store to hold ciphertext, found plaintext")
+		}
+	}
+
+	loaded, err := store.Load(s.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Messages) != 1 || loaded.Messages[0].Content != "this should not be stored in plaintext" {
+		t.Errorf("expected decrypted content to round-trip, got %+v", loaded.Messages)
+	}
+
+	if _, err := NewEncrypted(inner, ""); err == nil {
+		t.Error("expected error for empty key material")
+	}
+}
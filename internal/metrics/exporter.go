@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// Exporter receives the signals ExecutionStats tracks as a workflow runs, so
+// a long-running orka deployment can scrape or ship them instead of only
+// seeing a summary printed at the end of one run. PrometheusExporter and
+// OTLPExporter are the concrete implementations; NoopExporter is the
+// default, keeping behavior unchanged when no exporter is configured.
+type Exporter interface {
+	// RecordAgentDuration records one completed agent run's wall-clock time.
+	RecordAgentDuration(agentID, role, model string, d time.Duration)
+	// RecordAgentTokens records tokens consumed by model, direction being
+	// "input" or "output".
+	RecordAgentTokens(model, direction string, count int)
+	// RecordAgentCost records estimated USD cost attributed to model.
+	RecordAgentCost(model string, cost float64)
+	// IncAgentsCompleted increments the count of successfully completed agents.
+	IncAgentsCompleted()
+	// SetAgentsInflight reports how many agents are currently running.
+	SetAgentsInflight(n int)
+	// RecordToolCall records one tool invocation's outcome.
+	RecordToolCall(tool, status string)
+}
+
+// Tracer is an optional capability an Exporter may implement to also emit
+// distributed tracing spans (see OTLPExporter). Callers type-assert for it
+// rather than requiring every Exporter to support tracing, the same way
+// agent.StreamingClient is an optional capability of LLMClient.
+type Tracer interface {
+	// StartWorkflowSpan opens the root span for one workflow run. The
+	// returned context carries the span so StartAgentSpan can parent
+	// agent spans under it; the returned func ends the span.
+	StartWorkflowSpan(ctx context.Context, runID string) (context.Context, func())
+	// StartAgentSpan opens a span for one agent execution, as a child of
+	// whatever span ctx carries.
+	StartAgentSpan(ctx context.Context, agentID string) (context.Context, func())
+	// StartToolSpan opens a span for one tool call, as a child of whatever
+	// span ctx carries.
+	StartToolSpan(ctx context.Context, tool string) (context.Context, func())
+}
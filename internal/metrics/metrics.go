@@ -0,0 +1,189 @@
+// Package metrics exposes Prometheus counters and histograms for agent,
+// tool, and MCP execution. Each Metrics instance owns its own
+// prometheus.Registry so multiple Runners (e.g. in tests) don't collide on
+// the default global registry.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds all series emitted by a single workflow run.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	AgentRunsTotal          *prometheus.CounterVec
+	AgentDuration           *prometheus.HistogramVec
+	LLMGenerateDuration     *prometheus.HistogramVec
+	LLMRetriesTotal         *prometheus.CounterVec
+	ToolCallsTotal          *prometheus.CounterVec
+	ToolDuration            *prometheus.HistogramVec
+	MCPCallDuration         *prometheus.HistogramVec
+	SharedMemoryWaiters     prometheus.Gauge
+	BreakerTransitionsTotal *prometheus.CounterVec
+
+	// ExecutionStats-facing series (see Exporter): coarser, workflow-level
+	// signals meant for a dashboard, distinct from the per-call series above.
+	AgentDurationSeconds *prometheus.HistogramVec
+	AgentTokensTotal     *prometheus.CounterVec
+	AgentCostUSDTotal    *prometheus.CounterVec
+	AgentsCompletedTotal prometheus.Counter
+	AgentsInflight       prometheus.Gauge
+}
+
+// New creates a Metrics instance backed by its own registry and registers
+// all series on it.
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		AgentRunsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orkflow_agent_runs_total",
+			Help: "Total number of agent runs by agent, model, and outcome status.",
+		}, []string{"agent", "model", "status"}),
+		AgentDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orkflow_agent_duration_seconds",
+			Help:    "Duration of a full agent run, including retries and tool follow-ups.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"agent", "model"}),
+		LLMGenerateDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orkflow_llm_generate_duration_seconds",
+			Help:    "Duration of a single LLMClient.Generate call.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"provider", "model"}),
+		LLMRetriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orkflow_llm_retries_total",
+			Help: "Total number of LLM generate retries by provider and failure reason.",
+		}, []string{"provider", "reason"}),
+		ToolCallsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orkflow_tool_calls_total",
+			Help: "Total number of local tool executions by tool and outcome status.",
+		}, []string{"tool", "status"}),
+		ToolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orkflow_tool_duration_seconds",
+			Help:    "Duration of a local tool execution.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"tool"}),
+		MCPCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orkflow_mcp_call_duration_seconds",
+			Help:    "Duration of a remote MCP tool call.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"server", "tool"}),
+		SharedMemoryWaiters: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "orkflow_shared_memory_waiters",
+			Help: "Number of agents currently blocked in SharedMemory.WaitFor.",
+		}),
+		BreakerTransitionsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orkflow_circuit_breaker_transitions_total",
+			Help: "Total number of provider circuit breaker state transitions by provider and new state.",
+		}, []string{"provider", "state"}),
+		AgentDurationSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orka_agent_duration_seconds",
+			Help:    "Duration of a completed agent run by agent, role, and model.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"agent", "role", "model"}),
+		AgentTokensTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orka_agent_tokens_total",
+			Help: "Total tokens consumed by model and direction (input/output).",
+		}, []string{"direction", "model"}),
+		AgentCostUSDTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orka_agent_cost_usd_total",
+			Help: "Total estimated cost in USD by model.",
+		}, []string{"model"}),
+		AgentsCompletedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "orka_agents_completed_total",
+			Help: "Total number of agent runs that completed successfully.",
+		}),
+		AgentsInflight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "orka_agents_inflight",
+			Help: "Number of agents currently running.",
+		}),
+	}
+
+	registry.MustRegister(
+		m.AgentRunsTotal,
+		m.AgentDuration,
+		m.LLMGenerateDuration,
+		m.LLMRetriesTotal,
+		m.ToolCallsTotal,
+		m.ToolDuration,
+		m.MCPCallDuration,
+		m.SharedMemoryWaiters,
+		m.BreakerTransitionsTotal,
+		m.AgentDurationSeconds,
+		m.AgentTokensTotal,
+		m.AgentCostUSDTotal,
+		m.AgentsCompletedTotal,
+		m.AgentsInflight,
+	)
+
+	return m
+}
+
+// RecordAgentDuration implements Exporter.
+func (m *Metrics) RecordAgentDuration(agentID, role, model string, d time.Duration) {
+	m.AgentDurationSeconds.WithLabelValues(agentID, role, model).Observe(d.Seconds())
+}
+
+// RecordAgentTokens implements Exporter.
+func (m *Metrics) RecordAgentTokens(model, direction string, count int) {
+	m.AgentTokensTotal.WithLabelValues(direction, model).Add(float64(count))
+}
+
+// RecordAgentCost implements Exporter.
+func (m *Metrics) RecordAgentCost(model string, cost float64) {
+	m.AgentCostUSDTotal.WithLabelValues(model).Add(cost)
+}
+
+// IncAgentsCompleted implements Exporter.
+func (m *Metrics) IncAgentsCompleted() {
+	m.AgentsCompletedTotal.Inc()
+}
+
+// SetAgentsInflight implements Exporter.
+func (m *Metrics) SetAgentsInflight(n int) {
+	m.AgentsInflight.Set(float64(n))
+}
+
+// RecordToolCall implements Exporter, reusing the same series the mcp and
+// tools packages already increment directly.
+func (m *Metrics) RecordToolCall(tool, status string) {
+	m.ToolCallsTotal.WithLabelValues(tool, status).Inc()
+}
+
+var _ Exporter = (*Metrics)(nil)
+
+// PrometheusExporter is the Prometheus-backed Exporter implementation; it is
+// simply Metrics under the name the rest of the Exporter family uses.
+type PrometheusExporter = Metrics
+
+// NewPrometheusExporter creates a PrometheusExporter backed by its own
+// registry, ready to serve on --metrics-addr.
+func NewPrometheusExporter() *PrometheusExporter {
+	return New()
+}
+
+// Handler returns an http.Handler serving this instance's registry in the
+// Prometheus exposition format. If token is non-empty, requests must
+// present it as an HTTP Basic Auth password (any username is accepted).
+func (m *Metrics) Handler(token string) http.Handler {
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	if token == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pass, ok := r.BasicAuth()
+		if !ok || pass != token {
+			w.Header().Set("WWW-Authenticate", `Basic realm="orkflow metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import "time"
+
+// NoopExporter discards every signal. It's the default Exporter so behavior
+// is unchanged when metrics export isn't configured.
+type NoopExporter struct{}
+
+func (NoopExporter) RecordAgentDuration(agentID, role, model string, d time.Duration) {}
+func (NoopExporter) RecordAgentTokens(model, direction string, count int)             {}
+func (NoopExporter) RecordAgentCost(model string, cost float64)                       {}
+func (NoopExporter) IncAgentsCompleted()                                              {}
+func (NoopExporter) SetAgentsInflight(n int)                                          {}
+func (NoopExporter) RecordToolCall(tool, status string)                               {}
+
+var _ Exporter = NoopExporter{}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTLPExporter ships the same signals as PrometheusExporter as OpenTelemetry
+// metrics, plus spans: one per agent execution, one child span per tool
+// call, parented by a workflow-run span (when the caller threads the
+// context StartWorkflowSpan returns through to StartAgentSpan/StartToolSpan).
+type OTLPExporter struct {
+	meterProvider  *sdkmetric.MeterProvider
+	tracerProvider *sdktrace.TracerProvider
+	tracer         trace.Tracer
+
+	agentDuration   metric.Float64Histogram
+	agentTokens     metric.Int64Counter
+	agentCost       metric.Float64Counter
+	agentsCompleted metric.Int64Counter
+	agentsInflight  metric.Int64UpDownCounter
+	toolCalls       metric.Int64Counter
+
+	inflight int64 // last value passed to SetAgentsInflight, for delta tracking
+}
+
+// NewOTLPExporter dials endpoint (host:port of an OTLP gRPC collector) and
+// registers the orka_* metric instruments against it.
+func NewOTLPExporter(ctx context.Context, endpoint string) (*OTLPExporter, error) {
+	metricExporter, err := otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithEndpoint(endpoint),
+		otlpmetricgrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+	)
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+	)
+
+	meter := meterProvider.Meter("orkflow")
+
+	agentDuration, err := meter.Float64Histogram("orka_agent_duration_seconds",
+		metric.WithDescription("Duration of a completed agent run by agent, role, and model."))
+	if err != nil {
+		return nil, err
+	}
+	agentTokens, err := meter.Int64Counter("orka_agent_tokens_total",
+		metric.WithDescription("Total tokens consumed by model and direction."))
+	if err != nil {
+		return nil, err
+	}
+	agentCost, err := meter.Float64Counter("orka_agent_cost_usd_total",
+		metric.WithDescription("Total estimated cost in USD by model."))
+	if err != nil {
+		return nil, err
+	}
+	agentsCompleted, err := meter.Int64Counter("orka_agents_completed_total",
+		metric.WithDescription("Total number of agent runs that completed successfully."))
+	if err != nil {
+		return nil, err
+	}
+	agentsInflight, err := meter.Int64UpDownCounter("orka_agents_inflight",
+		metric.WithDescription("Number of agents currently running."))
+	if err != nil {
+		return nil, err
+	}
+	toolCalls, err := meter.Int64Counter("orka_tool_calls_total",
+		metric.WithDescription("Total number of tool executions by tool and outcome status."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OTLPExporter{
+		meterProvider:   meterProvider,
+		tracerProvider:  tracerProvider,
+		tracer:          tracerProvider.Tracer("orkflow"),
+		agentDuration:   agentDuration,
+		agentTokens:     agentTokens,
+		agentCost:       agentCost,
+		agentsCompleted: agentsCompleted,
+		agentsInflight:  agentsInflight,
+		toolCalls:       toolCalls,
+	}, nil
+}
+
+func (o *OTLPExporter) RecordAgentDuration(agentID, role, model string, d time.Duration) {
+	o.agentDuration.Record(context.Background(), d.Seconds(), metric.WithAttributes(
+		attribute.String("agent", agentID),
+		attribute.String("role", role),
+		attribute.String("model", model),
+	))
+}
+
+func (o *OTLPExporter) RecordAgentTokens(model, direction string, count int) {
+	o.agentTokens.Add(context.Background(), int64(count), metric.WithAttributes(
+		attribute.String("direction", direction),
+		attribute.String("model", model),
+	))
+}
+
+func (o *OTLPExporter) RecordAgentCost(model string, cost float64) {
+	o.agentCost.Add(context.Background(), cost, metric.WithAttributes(attribute.String("model", model)))
+}
+
+func (o *OTLPExporter) IncAgentsCompleted() {
+	o.agentsCompleted.Add(context.Background(), 1)
+}
+
+// SetAgentsInflight records n as a delta against an UpDownCounter, since
+// OTel has no direct gauge "set" on this instrument kind; callers always
+// pass the current total, not an increment.
+func (o *OTLPExporter) SetAgentsInflight(n int) {
+	prev := atomic.SwapInt64(&o.inflight, int64(n))
+	o.agentsInflight.Add(context.Background(), int64(n)-prev)
+}
+
+func (o *OTLPExporter) RecordToolCall(tool, status string) {
+	o.toolCalls.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("tool", tool),
+		attribute.String("status", status),
+	))
+}
+
+// StartWorkflowSpan implements Tracer.
+func (o *OTLPExporter) StartWorkflowSpan(ctx context.Context, runID string) (context.Context, func()) {
+	ctx, span := o.tracer.Start(ctx, "workflow.run", trace.WithAttributes(attribute.String("run_id", runID)))
+	return ctx, func() { span.End() }
+}
+
+// StartAgentSpan implements Tracer.
+func (o *OTLPExporter) StartAgentSpan(ctx context.Context, agentID string) (context.Context, func()) {
+	ctx, span := o.tracer.Start(ctx, "agent."+agentID)
+	return ctx, func() { span.End() }
+}
+
+// StartToolSpan implements Tracer.
+func (o *OTLPExporter) StartToolSpan(ctx context.Context, tool string) (context.Context, func()) {
+	ctx, span := o.tracer.Start(ctx, "tool."+tool)
+	return ctx, func() { span.End() }
+}
+
+// Shutdown flushes and closes the underlying OTLP metric and trace exporters.
+func (o *OTLPExporter) Shutdown(ctx context.Context) error {
+	if err := o.tracerProvider.Shutdown(ctx); err != nil {
+		return err
+	}
+	return o.meterProvider.Shutdown(ctx)
+}
+
+var (
+	_ Exporter = (*OTLPExporter)(nil)
+	_ Tracer   = (*OTLPExporter)(nil)
+)
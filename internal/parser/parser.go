@@ -0,0 +1,30 @@
+// Package parser loads a workflow YAML file into a types.WorkflowConfig.
+package parser
+
+import (
+	"fmt"
+	"os"
+
+	"Orkflow/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseYAML reads path and unmarshals it into a WorkflowConfig. It does no
+// validation beyond what YAML decoding itself catches (malformed syntax,
+// type mismatches) — structural checks (dangling references, cycles) live
+// in agent.Runner.Validate, which a caller that wants them runs separately
+// (`orka validate` does; run, serve, plan and runs do not).
+func ParseYAML(path string) (*types.WorkflowConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("parser: read %s: %w", path, err)
+	}
+
+	var config types.WorkflowConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parser: parse %s: %w", path, err)
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,142 @@
+// Package pricing externalizes LLM cost estimation into a versioned catalog
+// of provider/model rates, loaded from YAML or JSON, so new models and price
+// changes don't require a redeploy.
+package pricing
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default.yaml
+var defaultCatalogYAML []byte
+
+// Tier holds the per-1M-token rates (USD) for a single model, plus an
+// optional flat per-request fee for providers that charge one.
+type Tier struct {
+	Input       float64 `yaml:"input" json:"input"`
+	Output      float64 `yaml:"output" json:"output"`
+	CachedInput float64 `yaml:"cached_input,omitempty" json:"cached_input,omitempty"`
+	PerRequest  float64 `yaml:"per_request,omitempty" json:"per_request,omitempty"`
+}
+
+// Entry is one catalog row: a provider/model pair's pricing as of
+// EffectiveDate, so a run can be re-costed against the rates that were
+// actually in effect at the time it executed.
+type Entry struct {
+	Provider      string    `yaml:"provider" json:"provider"`
+	Model         string    `yaml:"model" json:"model"`
+	EffectiveDate time.Time `yaml:"effective_date" json:"effective_date"`
+	Tier          `yaml:",inline" json:",inline"`
+}
+
+// Key returns the catalog lookup key for a provider/model pair.
+func Key(provider, model string) string {
+	return provider + "/" + model
+}
+
+type catalogFile struct {
+	Entries []Entry `yaml:"entries" json:"entries"`
+}
+
+// Catalog is a provider/model -> pricing lookup table.
+type Catalog struct {
+	entries map[string]Entry
+}
+
+// NewCatalog returns an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{entries: make(map[string]Entry)}
+}
+
+// Add inserts or replaces an entry, keyed by provider/model.
+func (c *Catalog) Add(e Entry) {
+	c.entries[Key(e.Provider, e.Model)] = e
+}
+
+// Lookup returns the pricing entry for provider/model, if known.
+func (c *Catalog) Lookup(provider, model string) (Entry, bool) {
+	e, ok := c.entries[Key(provider, model)]
+	return e, ok
+}
+
+// LookupByModel scans for an entry matching model regardless of provider,
+// for callers that only have a bare model name (e.g. a display helper that
+// predates provider-qualified lookups). Prefer Lookup when the provider is
+// known, since model names aren't guaranteed unique across providers.
+func (c *Catalog) LookupByModel(model string) (Entry, bool) {
+	for _, e := range c.entries {
+		if e.Model == model {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Len reports how many entries the catalog holds.
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
+
+// DefaultCatalog returns the catalog embedded in the binary, used when no
+// --pricing-file / ~/.orka/pricing.yaml override is found.
+func DefaultCatalog() (*Catalog, error) {
+	return parseCatalog(defaultCatalogYAML, ".yaml")
+}
+
+// LoadCatalog reads a catalog from a YAML or JSON file on disk, selected by
+// its extension.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing file: %w", err)
+	}
+	return parseCatalog(data, filepath.Ext(path))
+}
+
+func parseCatalog(data []byte, ext string) (*Catalog, error) {
+	var file catalogFile
+
+	switch strings.ToLower(ext) {
+	case ".json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse pricing catalog: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse pricing catalog: %w", err)
+		}
+	}
+
+	catalog := NewCatalog()
+	for _, e := range file.Entries {
+		catalog.Add(e)
+	}
+	return catalog, nil
+}
+
+// LoadEffective resolves the catalog to use: an explicit path (from
+// --pricing-file) if given, else ~/.orka/pricing.yaml if it exists, else the
+// embedded default.
+func LoadEffective(pricingFile string) (*Catalog, error) {
+	if pricingFile != "" {
+		return LoadCatalog(pricingFile)
+	}
+
+	home, err := os.UserHomeDir()
+	if err == nil {
+		candidate := filepath.Join(home, ".orka", "pricing.yaml")
+		if _, statErr := os.Stat(candidate); statErr == nil {
+			return LoadCatalog(candidate)
+		}
+	}
+
+	return DefaultCatalog()
+}
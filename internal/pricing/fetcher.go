@@ -0,0 +1,113 @@
+package pricing
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// Fetcher periodically pulls an updated catalog from a URL, using an ETag so
+// unchanged catalogs don't re-parse or replace the cached one on every poll.
+type Fetcher struct {
+	URL    string
+	Client *http.Client
+
+	mu      sync.Mutex
+	etag    string
+	catalog *Catalog
+
+	stop chan struct{}
+}
+
+// NewFetcher creates a Fetcher for url, seeded with an initial catalog (e.g.
+// the embedded default) to serve until the first successful fetch completes.
+func NewFetcher(url string, initial *Catalog) *Fetcher {
+	return &Fetcher{
+		URL:     url,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+		catalog: initial,
+	}
+}
+
+// Catalog returns the most recently fetched catalog.
+func (f *Fetcher) Catalog() *Catalog {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.catalog
+}
+
+// Fetch pulls the catalog once, sending If-None-Match with the last seen
+// ETag. A 304 response leaves the cached catalog untouched and reports no
+// error.
+func (f *Fetcher) Fetch() error {
+	req, err := http.NewRequest(http.MethodGet, f.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	etag := f.etag
+	f.mu.Unlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pricing catalog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("pricing catalog fetch returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read pricing catalog response: %w", err)
+	}
+
+	catalog, err := parseCatalog(data, path.Ext(f.URL))
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.catalog = catalog
+	f.etag = resp.Header.Get("ETag")
+	f.mu.Unlock()
+
+	return nil
+}
+
+// Start polls Fetch every interval in the background until Stop is called.
+// Fetch errors are swallowed (the Fetcher keeps serving its last good
+// catalog); callers that care about errors should call Fetch directly.
+func (f *Fetcher) Start(interval time.Duration) {
+	f.stop = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = f.Fetch()
+			case <-f.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the background polling loop started by Start.
+func (f *Fetcher) Stop() {
+	if f.stop != nil {
+		close(f.stop)
+	}
+}
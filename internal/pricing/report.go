@@ -0,0 +1,39 @@
+package pricing
+
+// CostReport breaks an estimated cost down by agent and by model, and
+// records any models that had no catalog entry (and so were excluded from
+// TotalCost) so the caller can surface them instead of silently under-
+// reporting.
+type CostReport struct {
+	TotalCost     float64
+	ByAgent       map[string]float64
+	ByModel       map[string]float64
+	MissingModels []string
+}
+
+// NewCostReport returns an empty, ready-to-populate report.
+func NewCostReport() *CostReport {
+	return &CostReport{
+		ByAgent: make(map[string]float64),
+		ByModel: make(map[string]float64),
+	}
+}
+
+// Add records cost for agentID against model, updating both subtotals and
+// the running total.
+func (r *CostReport) Add(agentID, model string, cost float64) {
+	r.TotalCost += cost
+	r.ByAgent[agentID] += cost
+	r.ByModel[model] += cost
+}
+
+// AddMissing records that model had no catalog entry, unless it's already
+// been recorded.
+func (r *CostReport) AddMissing(model string) {
+	for _, m := range r.MissingModels {
+		if m == model {
+			return
+		}
+	}
+	r.MissingModels = append(r.MissingModels, model)
+}
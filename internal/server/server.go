@@ -0,0 +1,304 @@
+// Package server exposes a loaded workflow config as an OpenAI-compatible
+// HTTP API, so any OpenAI SDK or chat UI (LibreChat, Chatbot-UI, etc.) can
+// use orka as a drop-in backend.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"Orkflow/internal/engine"
+	"Orkflow/internal/memory"
+	"Orkflow/internal/vectorstore"
+	"Orkflow/pkg/types"
+)
+
+// Server serves config's agents and workflow over the OpenAI API shape.
+type Server struct {
+	Config *types.WorkflowConfig
+}
+
+// New creates a Server for config.
+func New(config *types.WorkflowConfig) *Server {
+	return &Server{Config: config}
+}
+
+// Handler returns the http.Handler serving /v1/chat/completions,
+// /v1/completions, /v1/embeddings, and /v1/models. Mount it directly, e.g.
+// http.ListenAndServe(addr, srv.Handler(token)). If token is non-empty,
+// every request must present it as an HTTP Basic Auth password (any
+// username is accepted) - the same scheme --metrics-token uses for
+// /metrics - since every one of these routes runs agents or tools and
+// otherwise anyone reaching the port can burn API keys at will.
+func (s *Server) Handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chat/completions", s.handleChatCompletions)
+	mux.HandleFunc("/v1/completions", s.handleCompletions)
+	mux.HandleFunc("/v1/embeddings", s.handleEmbeddings)
+	mux.HandleFunc("/v1/models", s.handleModels)
+	if token == "" {
+		return mux
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pass, ok := r.BasicAuth()
+		if !ok || pass != token {
+			w.Header().Set("WWW-Authenticate", `Basic realm="orka serve"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// run maps model to a named agent (running just that agent directly) or,
+// for any other name - including the synthetic "workflow" id /v1/models
+// advertises - the whole configured workflow via engine.Executor.Execute.
+// history is folded in as session history, the same mechanism `orka run
+// --continue --prompt` already uses to carry prior turns into a prompt.
+func (s *Server) run(ctx context.Context, model string, messages []chatMessage) (string, error) {
+	executor := engine.NewExecutor(s.Config)
+	executor.SetSessionHistory(buildHistory(messages))
+
+	if agentDef := executor.Runner.GetAgent(model); agentDef != nil {
+		return executor.Runner.RunAgent(ctx, agentDef)
+	}
+	return executor.Execute()
+}
+
+// buildHistory renders messages as session history in memory.Session's own
+// format, so agent prompts see prior chat turns exactly the way they'd see
+// a continued CLI session.
+func buildHistory(messages []chatMessage) string {
+	if len(messages) == 0 {
+		return ""
+	}
+
+	session := memory.NewSession("")
+	for _, m := range messages {
+		session.AddMessage(m.Role, "message", m.Content)
+	}
+	return session.GetHistory()
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	output, err := s.run(r.Context(), req.Model, req.Messages)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	id := "chatcmpl-" + memory.GenerateID()
+	created := time.Now().Unix()
+
+	if req.Stream {
+		writeChatStream(w, id, created, req.Model, output)
+		return
+	}
+
+	resp := chatCompletionResponse{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   req.Model,
+		Choices: []chatCompletionChoice{
+			{Index: 0, Message: chatMessage{Role: "assistant", Content: output}, FinishReason: strPtr("stop")},
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeChatStream streams output back as OpenAI-style
+// "chat.completion.chunk" SSE events, word by word, in the same "data: ..."
+// framing logging.HTTPHandler.serveEvents uses for live execution events,
+// then closes the stream with a final "data: [DONE]" event.
+func writeChatStream(w http.ResponseWriter, id string, created int64, model, output string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	send := func(delta chatMessage, finishReason *string) {
+		chunk := chatCompletionResponse{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []chatCompletionChoice{{Index: 0, Delta: delta, FinishReason: finishReason}},
+		}
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	send(chatMessage{Role: "assistant"}, nil)
+	for _, word := range strings.Fields(output) {
+		send(chatMessage{Content: word + " "}, nil)
+	}
+	send(chatMessage{}, strPtr("stop"))
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+func (s *Server) handleCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req completionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	output, err := s.run(r.Context(), req.Model, []chatMessage{{Role: "user", Content: req.Prompt}})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if req.Stream {
+		writeCompletionStream(w, req.Model, output)
+		return
+	}
+
+	resp := completionResponse{
+		ID:      "cmpl-" + memory.GenerateID(),
+		Object:  "text_completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []completionChoice{{Text: output, Index: 0, FinishReason: strPtr("stop")}},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeCompletionStream(w http.ResponseWriter, model, output string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	id := "cmpl-" + memory.GenerateID()
+	created := time.Now().Unix()
+
+	send := func(text string, finishReason *string) {
+		chunk := completionResponse{
+			ID:      id,
+			Object:  "text_completion",
+			Created: created,
+			Model:   model,
+			Choices: []completionChoice{{Text: text, Index: 0, FinishReason: finishReason}},
+		}
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	for _, word := range strings.Fields(output) {
+		send(word+" ", nil)
+	}
+	send("", strPtr("stop"))
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+func (s *Server) handleEmbeddings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req embeddingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	inputs, err := normalizeEmbeddingInput(req.Input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts := vectorstore.OptionsFromConfig(s.Config.Embeddings, "")
+	data := make([]embeddingData, 0, len(inputs))
+	for i, text := range inputs {
+		vec, err := vectorstore.Embed(r.Context(), opts, text)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		data = append(data, embeddingData{Object: "embedding", Embedding: vec, Index: i})
+	}
+
+	resp := embeddingResponse{Object: "list", Data: data, Model: req.Model}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// normalizeEmbeddingInput accepts OpenAI's two input shapes: a single
+// string, or an array of strings.
+func normalizeEmbeddingInput(input interface{}) ([]string, error) {
+	switch v := input.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		inputs := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("embeddings: input array must contain only strings")
+			}
+			inputs = append(inputs, text)
+		}
+		return inputs, nil
+	default:
+		return nil, fmt.Errorf("embeddings: input must be a string or array of strings")
+	}
+}
+
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data := []modelInfo{{ID: "workflow", Object: "model", OwnedBy: "orka"}}
+	for _, a := range s.Config.Agents {
+		data = append(data, modelInfo{ID: a.ID, Object: "model", OwnedBy: "orka"})
+	}
+
+	resp := modelListResponse{Object: "list", Data: data}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func strPtr(s string) *string { return &s }
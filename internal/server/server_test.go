@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"Orkflow/pkg/types"
+)
+
+func TestHandlerNoTokenAllowsUnauthenticated(t *testing.T) {
+	s := New(&types.WorkflowConfig{})
+	srv := httptest.NewServer(s.Handler(""))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/models")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200 when no token is configured", resp.StatusCode)
+	}
+}
+
+func TestHandlerRejectsMissingAuth(t *testing.T) {
+	s := New(&types.WorkflowConfig{})
+	srv := httptest.NewServer(s.Handler("secret"))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/models")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401 with no Authorization header", resp.StatusCode)
+	}
+}
+
+func TestHandlerRejectsWrongToken(t *testing.T) {
+	s := New(&types.WorkflowConfig{})
+	srv := httptest.NewServer(s.Handler("secret"))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/models", nil)
+	req.SetBasicAuth("anyone", "wrong-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401 with the wrong token", resp.StatusCode)
+	}
+}
+
+func TestHandlerAcceptsCorrectTokenAnyUsername(t *testing.T) {
+	s := New(&types.WorkflowConfig{})
+	srv := httptest.NewServer(s.Handler("secret"))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/models", nil)
+	req.SetBasicAuth("whoever", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200 with the correct token regardless of username", resp.StatusCode)
+	}
+}
@@ -21,6 +21,19 @@ func (c *CalcTool) Description() string {
 	return "Evaluate mathematical expressions. Supports +, -, *, /, %, ^, comparisons, and functions like abs(), max(), min(), len()."
 }
 
+func (c *CalcTool) JSONSchema() []byte {
+	return []byte(`{
+  "type": "object",
+  "properties": {
+    "input": {
+      "type": "string",
+      "description": "A mathematical expression, e.g. '2 + 2 * sin(3)'."
+    }
+  },
+  "required": ["input"]
+}`)
+}
+
 func (c *CalcTool) Execute(input string) (string, error) {
 	program, err := expr.Compile(input)
 	if err != nil {
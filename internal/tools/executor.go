@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
+
+	"Orkflow/internal/metrics"
+
+	"github.com/hashicorp/go-hclog"
 )
 
 // ToolCall represents a parsed tool invocation from LLM output
@@ -38,8 +43,14 @@ func ParseToolCalls(response string) []ToolCall {
 	return calls
 }
 
-// ExecuteToolCalls runs all parsed tool calls and returns results
-func ExecuteToolCalls(calls []ToolCall) []ToolResult {
+// ExecuteToolCalls runs all parsed tool calls and returns results. m is the
+// caller's metrics collector (a Runner's Metrics field) and may be nil, in
+// which case tool call metrics simply aren't recorded - passed explicitly
+// rather than through a package-level global so concurrent Runners (e.g. in
+// tests) never share collection state. log is likewise a Runner's
+// structured logger and may be nil, in which case tool execution is simply
+// not logged.
+func ExecuteToolCalls(calls []ToolCall, m *metrics.Metrics, log hclog.Logger) []ToolResult {
 	var results []ToolResult
 
 	for _, call := range calls {
@@ -52,8 +63,19 @@ func ExecuteToolCalls(calls []ToolCall) []ToolResult {
 			continue
 		}
 
-		fmt.Printf("  🔧 Executing tool: %s\n", call.Name)
+		if log != nil {
+			log.Info("executing tool", "tool", call.Name)
+		}
+		start := time.Now()
 		output, err := tool.Execute(call.Input)
+		if m != nil {
+			status := "success"
+			if err != nil {
+				status = "error"
+			}
+			m.ToolCallsTotal.WithLabelValues(call.Name, status).Inc()
+			m.ToolDuration.WithLabelValues(call.Name).Observe(time.Since(start).Seconds())
+		}
 		results = append(results, ToolResult{
 			ToolName: call.Name,
 			Output:   output,
@@ -22,6 +22,19 @@ func (f *FileTool) Description() string {
 	return "File operations. Commands: 'read:<path>' to read file, 'write:<path>:<content>' to write, 'list:<dir>' to list directory, 'exists:<path>' to check existence."
 }
 
+func (f *FileTool) JSONSchema() []byte {
+	return []byte(`{
+  "type": "object",
+  "properties": {
+    "input": {
+      "type": "string",
+      "description": "A command: 'read:<path>', 'write:<path>:<content>', 'list:<dir>', or 'exists:<path>'."
+    }
+  },
+  "required": ["input"]
+}`)
+}
+
 func (f *FileTool) Execute(input string) (string, error) {
 	input = strings.TrimSpace(input)
 
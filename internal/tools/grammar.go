@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// schemaNode is the subset of JSON Schema ToGBNF understands: object
+// (properties/required), string (optionally constrained to an enum),
+// number/integer, boolean, and array (homogeneous items). Every schema
+// registered by this package's own tools fits this subset; a schema that
+// needs $ref or oneOf falls back to the unconstrained json-value rule
+// below, which still forces syntactically valid JSON even though it can't
+// fully constrain the shape.
+type schemaNode struct {
+	Type       string                `json:"type"`
+	Properties map[string]schemaNode `json:"properties"`
+	Required   []string              `json:"required"`
+	Enum       []string              `json:"enum"`
+	Items      *schemaNode           `json:"items"`
+}
+
+// ToGBNF compiles toolList's aggregated JSON Schemas into a GBNF grammar
+// constraining output to {"name": "<tool>", "arguments": <tool's schema>}
+// for exactly one of toolList - the format llama.cpp-family servers accept
+// as a `grammar` request field. Pass the result to OllamaClient's or
+// GenericClient's GenerateWithTools so a local model can only emit a
+// structured tool call, not free text.
+func ToGBNF(toolList []Tool) (string, error) {
+	if len(toolList) == 0 {
+		return "", fmt.Errorf("no tools to build a grammar from")
+	}
+
+	var b strings.Builder
+
+	names := make([]string, len(toolList))
+	for i, t := range toolList {
+		names[i] = ruleName(t.Name()) + "-call"
+	}
+	sort.Strings(names)
+	fmt.Fprintf(&b, "root ::= %s\n", strings.Join(names, " | "))
+
+	for _, t := range toolList {
+		var node schemaNode
+		if err := json.Unmarshal(t.JSONSchema(), &node); err != nil {
+			return "", fmt.Errorf("tool %s: invalid JSON schema: %w", t.Name(), err)
+		}
+
+		rule := ruleName(t.Name())
+		argsRule := rule + "-args"
+		fmt.Fprintf(&b, "%s-call ::= \"{\" ws \"\\\"name\\\"\" ws \":\" ws \"\\\"%s\\\"\" ws \",\" ws \"\\\"arguments\\\"\" ws \":\" ws %s ws \"}\"\n",
+			rule, t.Name(), argsRule)
+		writeObjectRule(&b, argsRule, node)
+	}
+
+	b.WriteString(jsonPrimitivesGBNF)
+	return b.String(), nil
+}
+
+// writeObjectRule emits a GBNF rule for an object schema node, with one
+// sub-rule per property. Properties are always emitted in a fixed order,
+// sorted by name, and treated as all-present - this
+// package's own tool schemas have exactly one required string property,
+// so that simplification never bites in practice.
+func writeObjectRule(b *strings.Builder, rule string, node schemaNode) {
+	if node.Type != "object" || len(node.Properties) == 0 {
+		fmt.Fprintf(b, "%s ::= json-value\n", rule)
+		return
+	}
+
+	keys := make([]string, 0, len(node.Properties))
+	for k := range node.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		valueRule := rule + "-" + ruleName(k)
+		sep := ""
+		if i < len(keys)-1 {
+			sep = " \",\" ws"
+		}
+		parts[i] = fmt.Sprintf("\"\\\"%s\\\"\" ws \":\" ws %s%s", k, valueRule, sep)
+		writeValueRule(b, valueRule, node.Properties[k])
+	}
+
+	fmt.Fprintf(b, "%s ::= \"{\" ws %s ws \"}\"\n", rule, strings.Join(parts, " "))
+}
+
+// writeValueRule emits a GBNF rule constraining a single property's value.
+func writeValueRule(b *strings.Builder, rule string, node schemaNode) {
+	switch node.Type {
+	case "string":
+		if len(node.Enum) > 0 {
+			alts := make([]string, len(node.Enum))
+			for i, e := range node.Enum {
+				alts[i] = fmt.Sprintf("\"\\\"%s\\\"\"", e)
+			}
+			fmt.Fprintf(b, "%s ::= %s\n", rule, strings.Join(alts, " | "))
+			return
+		}
+		fmt.Fprintf(b, "%s ::= string\n", rule)
+	case "number", "integer":
+		fmt.Fprintf(b, "%s ::= number\n", rule)
+	case "boolean":
+		fmt.Fprintf(b, "%s ::= boolean\n", rule)
+	case "array":
+		item := rule + "-item"
+		if node.Items != nil {
+			writeValueRule(b, item, *node.Items)
+		} else {
+			fmt.Fprintf(b, "%s ::= json-value\n", item)
+		}
+		fmt.Fprintf(b, "%s ::= \"[\" ws (%s (\",\" ws %s)*)? ws \"]\"\n", rule, item, item)
+	case "object":
+		writeObjectRule(b, rule, node)
+	default:
+		fmt.Fprintf(b, "%s ::= json-value\n", rule)
+	}
+}
+
+// jsonPrimitivesGBNF defines the leaf rules every tool's grammar shares:
+// whitespace, quoted strings, numbers, booleans, and a catch-all
+// json-value for schema shapes ToGBNF doesn't constrain further.
+const jsonPrimitivesGBNF = `ws ::= [ \t\n\r]*
+string ::= "\"" ([^"\\] | "\\" .)* "\""
+number ::= "-"? [0-9]+ ("." [0-9]+)?
+boolean ::= "true" | "false"
+json-value ::= string | number | boolean | "null" | "{" ws "}" | "[" ws "]"
+`
+
+// ruleName sanitizes a tool name (which may contain "." for MCP toolset
+// members) into a bare GBNF rule identifier.
+func ruleName(toolName string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(toolName)
+}
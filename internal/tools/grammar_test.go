@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToGBNFNoTools(t *testing.T) {
+	if _, err := ToGBNF(nil); err == nil {
+		t.Error("ToGBNF(nil) error = nil, want an error")
+	}
+}
+
+func TestToGBNFProducesRootAndCallRules(t *testing.T) {
+	calc, ok := Get("calc")
+	if !ok {
+		t.Fatal("calc tool not registered")
+	}
+
+	grammar, err := ToGBNF([]Tool{calc})
+	if err != nil {
+		t.Fatalf("ToGBNF() error = %v", err)
+	}
+
+	if !strings.Contains(grammar, "root ::= calc-call") {
+		t.Errorf("grammar = %q, want a root rule referencing calc-call", grammar)
+	}
+	if !strings.Contains(grammar, `\"name\"`) {
+		t.Errorf("grammar = %q, want the call rule to emit a name key", grammar)
+	}
+	if !strings.Contains(grammar, "calc-call ::=") {
+		t.Errorf("grammar = %q, want a calc-call rule", grammar)
+	}
+	if !strings.Contains(grammar, "calc-args") {
+		t.Errorf("grammar = %q, want a calc-args rule", grammar)
+	}
+}
+
+func TestRuleNameSanitizesDotsAndUnderscores(t *testing.T) {
+	if got := ruleName("server.read_file"); got != "server-read-file" {
+		t.Errorf("ruleName() = %q, want server-read-file", got)
+	}
+}
@@ -10,6 +10,12 @@ type Tool interface {
 	Name() string
 	Description() string
 	Execute(input string) (string, error)
+	// JSONSchema returns a JSON Schema object describing this tool's
+	// Execute input, e.g. {"type":"object","properties":{"input":{"type":
+	// "string"}},"required":["input"]}. Used to build the `tools` array
+	// sent to providers with native function calling and the GBNF grammar
+	// generated for local models - see BuildToolSpecs and ToGBNF.
+	JSONSchema() []byte
 }
 
 // Registry holds all available tools
@@ -0,0 +1,36 @@
+package tools
+
+import "encoding/json"
+
+// FunctionSpec is one entry of an OpenAI-style `tools` array: a function
+// the model may call, described by its JSON Schema parameters.
+type FunctionSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ToolSpec wraps a FunctionSpec the way OpenAI/Claude/Gemini's native
+// function-calling request fields expect a `tools` array entry to look.
+type ToolSpec struct {
+	Type     string       `json:"type"`
+	Function FunctionSpec `json:"function"`
+}
+
+// BuildToolSpecs converts toolList into an OpenAI-compatible `tools`
+// array, for GenerateWithTools implementations that support native
+// function calling (OpenAIClient, ClaudeClient, GeminiClient).
+func BuildToolSpecs(toolList []Tool) []ToolSpec {
+	specs := make([]ToolSpec, 0, len(toolList))
+	for _, t := range toolList {
+		specs = append(specs, ToolSpec{
+			Type: "function",
+			Function: FunctionSpec{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  json.RawMessage(t.JSONSchema()),
+			},
+		})
+	}
+	return specs
+}
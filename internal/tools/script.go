@@ -22,6 +22,19 @@ func (s *ScriptTool) Description() string {
 	return "Execute scripts using Tengo (Go-like syntax). Supports variables, loops, functions, math, and string operations. Set 'output' variable to return a value."
 }
 
+func (s *ScriptTool) JSONSchema() []byte {
+	return []byte(`{
+  "type": "object",
+  "properties": {
+    "input": {
+      "type": "string",
+      "description": "A Tengo script body; set the 'output' variable to return a value."
+    }
+  },
+  "required": ["input"]
+}`)
+}
+
 func (s *ScriptTool) Execute(input string) (string, error) {
 	// Wrap script to capture output variable
 	wrappedScript := fmt.Sprintf(`
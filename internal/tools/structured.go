@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StructuredToolCall is a {"name", "arguments"} tool invocation - the
+// shape ToGBNF constrains local models to and the shape OpenAI/Claude/
+// Gemini's native function-calling responses are normalized into by each
+// ToolCallingClient implementation.
+type StructuredToolCall struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// ParseStructuredToolCall decodes response as a single StructuredToolCall.
+// Callers try this before falling back to the regex ```tool:name``` fence
+// format, since a ToolCallingClient response is plain JSON rather than a
+// fenced code block.
+func ParseStructuredToolCall(response string) (*StructuredToolCall, error) {
+	var call StructuredToolCall
+	if err := json.Unmarshal([]byte(strings.TrimSpace(response)), &call); err != nil {
+		return nil, fmt.Errorf("not a structured tool call: %w", err)
+	}
+	if call.Name == "" {
+		return nil, fmt.Errorf("structured tool call missing name")
+	}
+	return &call, nil
+}
+
+// ToToolCall validates the call's arguments against its tool's declared
+// JSON Schema and adapts it to the ToolCall{Name,Input} shape
+// ExecuteToolCalls already knows how to run.
+func (c StructuredToolCall) ToToolCall() (ToolCall, error) {
+	tool, ok := Get(c.Name)
+	if !ok {
+		return ToolCall{}, fmt.Errorf("unknown tool: %s", c.Name)
+	}
+	if err := ValidateArguments(tool.JSONSchema(), c.Arguments); err != nil {
+		return ToolCall{}, fmt.Errorf("tool %s: %w", c.Name, err)
+	}
+	input, _ := c.Arguments["input"].(string)
+	return ToolCall{Name: c.Name, Input: input}, nil
+}
+
+// ValidateArguments checks args against schema's required properties and
+// per-property types, so a malformed structured tool call is rejected
+// before it reaches Tool.Execute rather than failing inside it.
+func ValidateArguments(schema []byte, args map[string]interface{}) error {
+	var node schemaNode
+	if err := json.Unmarshal(schema, &node); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	for _, req := range node.Required {
+		if _, ok := args[req]; !ok {
+			return fmt.Errorf("missing required argument %q", req)
+		}
+	}
+
+	for name, value := range args {
+		prop, ok := node.Properties[name]
+		if !ok {
+			continue // schemas here don't forbid additional properties
+		}
+		if !argTypeMatches(prop.Type, value) {
+			return fmt.Errorf("argument %q: expected %s, got %T", name, prop.Type, value)
+		}
+	}
+
+	return nil
+}
+
+// argTypeMatches reports whether value, as decoded by encoding/json, is
+// consistent with a JSON Schema primitive type name.
+func argTypeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number", "integer":
+		_, ok := value.(float64) // encoding/json decodes all JSON numbers as float64
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return true
+	}
+}
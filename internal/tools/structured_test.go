@@ -0,0 +1,66 @@
+package tools
+
+import "testing"
+
+func TestParseStructuredToolCall(t *testing.T) {
+	call, err := ParseStructuredToolCall(`{"name": "calc", "arguments": {"input": "2+2"}}`)
+	if err != nil {
+		t.Fatalf("ParseStructuredToolCall() error = %v", err)
+	}
+	if call.Name != "calc" || call.Arguments["input"] != "2+2" {
+		t.Errorf("ParseStructuredToolCall() = %+v", call)
+	}
+}
+
+func TestParseStructuredToolCallNotJSON(t *testing.T) {
+	if _, err := ParseStructuredToolCall("not json at all"); err == nil {
+		t.Error("ParseStructuredToolCall() error = nil, want an error for non-JSON input")
+	}
+}
+
+func TestParseStructuredToolCallMissingName(t *testing.T) {
+	if _, err := ParseStructuredToolCall(`{"arguments": {"input": "2+2"}}`); err == nil {
+		t.Error("ParseStructuredToolCall() error = nil, want an error when name is missing")
+	}
+}
+
+func TestStructuredToolCallToToolCall(t *testing.T) {
+	call := StructuredToolCall{Name: "calc", Arguments: map[string]interface{}{"input": "2+2"}}
+
+	tc, err := call.ToToolCall()
+	if err != nil {
+		t.Fatalf("ToToolCall() error = %v", err)
+	}
+	if tc.Name != "calc" || tc.Input != "2+2" {
+		t.Errorf("ToToolCall() = %+v", tc)
+	}
+}
+
+func TestStructuredToolCallToToolCallUnknownTool(t *testing.T) {
+	call := StructuredToolCall{Name: "does-not-exist", Arguments: map[string]interface{}{"input": "x"}}
+	if _, err := call.ToToolCall(); err == nil {
+		t.Error("ToToolCall() error = nil, want an error for an unregistered tool")
+	}
+}
+
+func TestStructuredToolCallToToolCallMissingRequiredArgument(t *testing.T) {
+	call := StructuredToolCall{Name: "calc", Arguments: map[string]interface{}{}}
+	if _, err := call.ToToolCall(); err == nil {
+		t.Error("ToToolCall() error = nil, want an error when a required argument is missing")
+	}
+}
+
+func TestValidateArgumentsTypeMismatch(t *testing.T) {
+	schema := []byte(`{"type":"object","properties":{"input":{"type":"string"}},"required":["input"]}`)
+	err := ValidateArguments(schema, map[string]interface{}{"input": 123})
+	if err == nil {
+		t.Error("ValidateArguments() error = nil, want a type mismatch error")
+	}
+}
+
+func TestValidateArgumentsOK(t *testing.T) {
+	schema := []byte(`{"type":"object","properties":{"input":{"type":"string"}},"required":["input"]}`)
+	if err := ValidateArguments(schema, map[string]interface{}{"input": "2+2"}); err != nil {
+		t.Errorf("ValidateArguments() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,292 @@
+package vectorstore
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	bm25SidecarFile = "bm25.db"
+)
+
+var (
+	bm25PostingsBucket = []byte("postings")    // term -> json []posting
+	bm25DocLenBucket   = []byte("doc_lengths") // docID -> token count (binary uint64)
+	bm25MetaBucket     = []byte("meta")        // "doc_count", "total_length" (binary uint64)
+)
+
+// posting is one document's term frequency within a single postings list.
+type posting struct {
+	DocID string `json:"doc_id"`
+	Freq  int    `json:"freq"`
+}
+
+// scoredDoc is a ranked BM25 (or fused) result.
+type scoredDoc struct {
+	DocID string
+	Score float64
+}
+
+// bm25Index is an in-process Okapi BM25 index over document content,
+// persisted to a bbolt sidecar file next to a store's persistent directory
+// so keyword search survives restarts without re-tokenizing every document.
+// Tokenization is unicode letters+digits, lowercased.
+type bm25Index struct {
+	db *bbolt.DB
+}
+
+func newBM25Index(dbPath string) (*bm25Index, error) {
+	db, err := bbolt.Open(filepath.Join(dbPath, bm25SidecarFile), 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: open bm25 sidecar: %w", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, b := range [][]byte{bm25PostingsBucket, bm25DocLenBucket, bm25MetaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &bm25Index{db: db}, nil
+}
+
+func (idx *bm25Index) Close() error {
+	return idx.db.Close()
+}
+
+func tokenize(text string) []string {
+	var tokens []string
+	var cur strings.Builder
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			cur.WriteRune(unicode.ToLower(r))
+		case cur.Len() > 0:
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
+
+func uniqueTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	var out []string
+	for _, t := range tokens {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Add tokenizes content and updates postings, doc length, and corpus totals
+// for docID, replacing any prior entry for the same ID.
+func (idx *bm25Index) Add(docID string, content string) error {
+	if err := idx.Delete(docID); err != nil {
+		return err
+	}
+
+	tokens := tokenize(content)
+	termFreq := make(map[string]int)
+	for _, t := range tokens {
+		termFreq[t]++
+	}
+
+	return idx.db.Update(func(tx *bbolt.Tx) error {
+		postings := tx.Bucket(bm25PostingsBucket)
+		for term, freq := range termFreq {
+			var list []posting
+			if raw := postings.Get([]byte(term)); raw != nil {
+				if err := json.Unmarshal(raw, &list); err != nil {
+					return err
+				}
+			}
+			list = append(list, posting{DocID: docID, Freq: freq})
+			encoded, err := json.Marshal(list)
+			if err != nil {
+				return err
+			}
+			if err := postings.Put([]byte(term), encoded); err != nil {
+				return err
+			}
+		}
+
+		docLens := tx.Bucket(bm25DocLenBucket)
+		if err := docLens.Put([]byte(docID), encodeUint64(uint64(len(tokens)))); err != nil {
+			return err
+		}
+
+		return adjustBM25Totals(tx, 1, len(tokens))
+	})
+}
+
+// Delete removes docID's postings and doc length, if present. It is safe to
+// call on a docID that was never indexed.
+func (idx *bm25Index) Delete(docID string) error {
+	return idx.db.Update(func(tx *bbolt.Tx) error {
+		docLens := tx.Bucket(bm25DocLenBucket)
+		raw := docLens.Get([]byte(docID))
+		if raw == nil {
+			return nil
+		}
+		oldLen := int(decodeUint64(raw))
+
+		postings := tx.Bucket(bm25PostingsBucket)
+		c := postings.Cursor()
+		for term, data := c.First(); term != nil; term, data = c.Next() {
+			var list []posting
+			if err := json.Unmarshal(data, &list); err != nil {
+				return err
+			}
+			filtered := list[:0]
+			for _, p := range list {
+				if p.DocID != docID {
+					filtered = append(filtered, p)
+				}
+			}
+			if len(filtered) == 0 {
+				if err := postings.Delete(term); err != nil {
+					return err
+				}
+			} else {
+				encoded, err := json.Marshal(filtered)
+				if err != nil {
+					return err
+				}
+				if err := postings.Put(term, encoded); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := docLens.Delete([]byte(docID)); err != nil {
+			return err
+		}
+		return adjustBM25Totals(tx, -1, -oldLen)
+	})
+}
+
+func adjustBM25Totals(tx *bbolt.Tx, deltaDocs, deltaLen int) error {
+	meta := tx.Bucket(bm25MetaBucket)
+
+	docCount := int64(decodeUint64(meta.Get([]byte("doc_count")))) + int64(deltaDocs)
+	totalLen := int64(decodeUint64(meta.Get([]byte("total_length")))) + int64(deltaLen)
+	if docCount < 0 {
+		docCount = 0
+	}
+	if totalLen < 0 {
+		totalLen = 0
+	}
+
+	if err := meta.Put([]byte("doc_count"), encodeUint64(uint64(docCount))); err != nil {
+		return err
+	}
+	return meta.Put([]byte("total_length"), encodeUint64(uint64(totalLen)))
+}
+
+// Search ranks documents by Okapi BM25 score against query, returning at
+// most limit (docID, score) pairs sorted best-first.
+func (idx *bm25Index) Search(query string, limit int) ([]scoredDoc, error) {
+	var results []scoredDoc
+
+	err := idx.db.View(func(tx *bbolt.Tx) error {
+		meta := tx.Bucket(bm25MetaBucket)
+		docCount := float64(decodeUint64(meta.Get([]byte("doc_count"))))
+		if docCount == 0 {
+			return nil
+		}
+		avgDocLen := float64(decodeUint64(meta.Get([]byte("total_length")))) / docCount
+
+		docLens := tx.Bucket(bm25DocLenBucket)
+		postings := tx.Bucket(bm25PostingsBucket)
+
+		scores := make(map[string]float64)
+		for _, term := range uniqueTokens(tokenize(query)) {
+			raw := postings.Get([]byte(term))
+			if raw == nil {
+				continue
+			}
+			var list []posting
+			if err := json.Unmarshal(raw, &list); err != nil {
+				return err
+			}
+
+			idf := math.Log(1 + (docCount-float64(len(list))+0.5)/(float64(len(list))+0.5))
+			for _, p := range list {
+				docLen := float64(decodeUint64(docLens.Get([]byte(p.DocID))))
+				tf := float64(p.Freq)
+				norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgDocLen))
+				scores[p.DocID] += idf * norm
+			}
+		}
+
+		for docID, score := range scores {
+			results = append(results, scoredDoc{DocID: docID, Score: score})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// reciprocalRankFusion fuses multiple ranked result lists into one, scoring
+// each document by Σ 1/(60 + rank) across every list it appears in.
+func reciprocalRankFusion(rankedLists ...[]string) []string {
+	const k = 60
+	scores := make(map[string]float64)
+	for _, list := range rankedLists {
+		for rank, docID := range list {
+			scores[docID] += 1 / float64(k+rank+1)
+		}
+	}
+
+	docIDs := make([]string, 0, len(scores))
+	for docID := range scores {
+		docIDs = append(docIDs, docID)
+	}
+	sort.Slice(docIDs, func(i, j int) bool { return scores[docIDs[i]] > scores[docIDs[j]] })
+	return docIDs
+}
+
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func decodeUint64(b []byte) uint64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
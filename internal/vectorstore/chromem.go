@@ -0,0 +1,245 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/philippgille/chromem-go"
+)
+
+func init() {
+	Register("chromem", chromemDriver{})
+}
+
+// chromemDriver opens a ChromemStore, the embedded on-disk default backend.
+// Its DSN (and Options.Path, which takes precedence) is the directory to
+// persist to; an empty one defaults to ~/.orka/vectordb.
+type chromemDriver struct{}
+
+func (chromemDriver) Open(dsn string, opts Options) (VectorStore, error) {
+	ef, err := embeddingFuncFor(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	path := opts.Path
+	if path == "" {
+		path = dsn
+	}
+	if path == "" {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, VectorDBPath)
+	}
+
+	return newChromemStore(path, chromem.EmbeddingFunc(ef))
+}
+
+// ChromemStore implements VectorStore using chromem-go (embedded). It also
+// maintains a bm25Index sidecar so it can satisfy HybridSearcher.
+type ChromemStore struct {
+	db         *chromem.DB
+	collection *chromem.Collection
+	ctx        context.Context
+	bm25       *bm25Index
+}
+
+// newChromemStore opens (or creates) a persistent chromem DB and its bm25
+// sidecar at dbPath with the given embedding function. dbPath is
+// caller-supplied so tests can point it at a temp directory instead of the
+// real ~/.orka/vectordb.
+func newChromemStore(dbPath string, ef chromem.EmbeddingFunc) (*ChromemStore, error) {
+	if err := os.MkdirAll(dbPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create vectordb directory: %w", err)
+	}
+
+	ctx := context.Background()
+
+	db, err := chromem.NewPersistentDB(dbPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chromem db: %w", err)
+	}
+
+	collection, err := db.GetOrCreateCollection(CollectionName, nil, ef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get/create collection: %w", err)
+	}
+
+	bm25, err := newBM25Index(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChromemStore{
+		db:         db,
+		collection: collection,
+		ctx:        ctx,
+		bm25:       bm25,
+	}, nil
+}
+
+// AddDocument adds a document to the vector store and its bm25 index
+func (c *ChromemStore) AddDocument(id string, content string, metadata map[string]string) error {
+	if err := c.collection.AddDocument(c.ctx, chromem.Document{
+		ID:       id,
+		Content:  content,
+		Metadata: metadata,
+	}); err != nil {
+		return err
+	}
+	if err := c.bm25.Add(id, content); err != nil {
+		return fmt.Errorf("failed to update bm25 index: %w", err)
+	}
+	return nil
+}
+
+// AddDocuments adds multiple documents at once
+func (c *ChromemStore) AddDocuments(docs []chromem.Document) error {
+	if err := c.collection.AddDocuments(c.ctx, docs, 4); err != nil { // Use 4 concurrent goroutines
+		return err
+	}
+	for _, doc := range docs {
+		if err := c.bm25.Add(doc.ID, doc.Content); err != nil {
+			return fmt.Errorf("failed to update bm25 index: %w", err)
+		}
+	}
+	return nil
+}
+
+// Search finds similar documents by dense embedding similarity
+func (c *ChromemStore) Search(query string, limit int) ([]SearchResult, error) {
+	return c.SearchWithOptions(query, SearchOptions{Limit: limit, Mode: Dense})
+}
+
+// SearchWithOptions implements HybridSearcher: Dense ranks by embedding
+// similarity, Keyword by BM25, and Hybrid fuses both with Reciprocal Rank
+// Fusion. Where/WhereDocument are passed through to chromem for Dense mode.
+func (c *ChromemStore) SearchWithOptions(query string, opts SearchOptions) ([]SearchResult, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	switch opts.Mode {
+	case Keyword:
+		return c.searchKeyword(query, limit)
+	case Hybrid:
+		return c.searchHybrid(query, limit)
+	default:
+		return c.searchDense(query, limit, opts.Where, opts.WhereDocument)
+	}
+}
+
+func (c *ChromemStore) searchDense(query string, limit int, where map[string]string, whereDocument string) ([]SearchResult, error) {
+	var whereDoc map[string]string
+	if whereDocument != "" {
+		whereDoc = map[string]string{"$contains": whereDocument}
+	}
+
+	results, err := c.collection.Query(c.ctx, query, limit, where, whereDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+
+	var searchResults []SearchResult
+	for _, r := range results {
+		searchResults = append(searchResults, SearchResult{
+			ID:       r.ID,
+			Content:  r.Content,
+			Score:    r.Similarity,
+			Metadata: r.Metadata,
+		})
+	}
+
+	return searchResults, nil
+}
+
+func (c *ChromemStore) searchKeyword(query string, limit int) ([]SearchResult, error) {
+	scored, err := c.bm25.Search(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bm25 index: %w", err)
+	}
+	return c.hydrate(scored)
+}
+
+func (c *ChromemStore) searchHybrid(query string, limit int) ([]SearchResult, error) {
+	const k = 3 // fetch k times the final limit from each retriever before fusing
+
+	dense, err := c.searchDense(query, limit*k, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	keyword, err := c.searchKeyword(query, limit*k)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]SearchResult, len(dense)+len(keyword))
+	denseIDs := make([]string, len(dense))
+	for i, r := range dense {
+		denseIDs[i] = r.ID
+		byID[r.ID] = r
+	}
+	keywordIDs := make([]string, len(keyword))
+	for i, r := range keyword {
+		keywordIDs[i] = r.ID
+		if _, ok := byID[r.ID]; !ok {
+			byID[r.ID] = r
+		}
+	}
+
+	fused := reciprocalRankFusion(denseIDs, keywordIDs)
+	if len(fused) > limit {
+		fused = fused[:limit]
+	}
+
+	results := make([]SearchResult, 0, len(fused))
+	for _, id := range fused {
+		results = append(results, byID[id])
+	}
+	return results, nil
+}
+
+// hydrate looks up each bm25 hit's content and metadata in chromem, since
+// the bm25 index only stores postings and doc lengths. A document present
+// in one index but not (yet) the other is skipped rather than failing the
+// whole search.
+func (c *ChromemStore) hydrate(scored []scoredDoc) ([]SearchResult, error) {
+	var out []SearchResult
+	for _, s := range scored {
+		doc, err := c.collection.GetByID(c.ctx, s.DocID)
+		if err != nil {
+			continue
+		}
+		out = append(out, SearchResult{
+			ID:       doc.ID,
+			Content:  doc.Content,
+			Score:    float32(s.Score),
+			Metadata: doc.Metadata,
+		})
+	}
+	return out, nil
+}
+
+// DeleteDocument removes a document from the store and its bm25 index
+func (c *ChromemStore) DeleteDocument(id string) error {
+	if err := c.collection.Delete(c.ctx, nil, nil, id); err != nil {
+		return err
+	}
+	if err := c.bm25.Delete(id); err != nil {
+		return fmt.Errorf("failed to update bm25 index: %w", err)
+	}
+	return nil
+}
+
+// Close closes the bm25 sidecar (chromem-go's persistent storage handles
+// its own cleanup and needs no explicit close)
+func (c *ChromemStore) Close() error {
+	return c.bm25.Close()
+}
+
+// GetCollection returns the underlying collection for advanced operations
+func (c *ChromemStore) GetCollection() *chromem.Collection {
+	return c.collection
+}
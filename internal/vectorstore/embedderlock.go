@@ -0,0 +1,44 @@
+package vectorstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const embedderLockFile = "embedder.lock"
+
+// CheckEmbedderLock compares identity (see EmbedderIdentity) against the
+// embedder last used to index dir, erroring if they differ so switching
+// --embed-provider/--embed-model mid-stream can't silently mix incompatible
+// vectors into one collection. The first call for a fresh dir just records
+// identity and succeeds, the same sidecar-file pattern bm25Index uses
+// alongside a store's persistent directory.
+func CheckEmbedderLock(dir string, identity string) error {
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, VectorDBPath)
+	}
+	lockPath := filepath.Join(dir, embedderLockFile)
+
+	if raw, err := os.ReadFile(lockPath); err == nil {
+		var recorded string
+		if err := json.Unmarshal(raw, &recorded); err != nil {
+			return fmt.Errorf("vectorstore: read embedder lock %s: %w", lockPath, err)
+		}
+		if recorded != "" && recorded != identity {
+			return fmt.Errorf("vectorstore: index at %s was built with embedder %q, not %q - use a different path or re-index from scratch", dir, recorded, identity)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("vectorstore: create %s: %w", dir, err)
+	}
+	encoded, err := json.Marshal(identity)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(lockPath, encoded, 0644)
+}
@@ -0,0 +1,177 @@
+package vectorstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Embedder computes an embedding vector for a chunk of text. It's the
+// interface form of EmbeddingFunc, for backends (gemini, cohere) that call
+// a provider's HTTP API directly instead of going through chromem-go, which
+// doesn't support them. embeddingFuncFor adapts an Embedder to an
+// EmbeddingFunc via its Embed method value.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// geminiEmbedder calls Gemini's embedContent endpoint, mirroring the plain
+// net/http style agent.GeminiClient already uses for chat completions.
+type geminiEmbedder struct {
+	apiKey string
+	model  string
+}
+
+func (g geminiEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:embedContent?key=%s", g.model, g.apiKey)
+
+	payload := map[string]interface{}{
+		"model": "models/" + g.model,
+		"content": map[string]interface{}{
+			"parts": []map[string]string{{"text": text}},
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("gemini embeddings api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Embedding.Values, nil
+}
+
+// cohereEmbedder calls Cohere's /v1/embed endpoint.
+type cohereEmbedder struct {
+	apiKey string
+	model  string
+}
+
+func (c cohereEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	payload := map[string]interface{}{
+		"model":      c.model,
+		"texts":      []string{text},
+		"input_type": "search_document",
+	}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.cohere.com/v1/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("cohere embeddings api error: %s", string(respBody))
+	}
+
+	var result struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Embeddings) == 0 {
+		return nil, fmt.Errorf("cohere: no embedding returned for text")
+	}
+	return result.Embeddings[0], nil
+}
+
+// huggingFaceEmbedder calls the Hugging Face Inference API's
+// feature-extraction endpoint. Sentence-embedding models return a single
+// flat vector per input; other feature-extraction models return one vector
+// per token, which Embed mean-pools into a single vector.
+type huggingFaceEmbedder struct {
+	apiKey string
+	model  string
+}
+
+func (h huggingFaceEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	url := "https://api-inference.huggingface.co/models/" + h.model
+
+	payload := map[string]interface{}{"inputs": text}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+h.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("huggingface embeddings api error: %s", string(respBody))
+	}
+
+	var flat []float32
+	if err := json.Unmarshal(respBody, &flat); err == nil {
+		return flat, nil
+	}
+
+	var tokens [][]float32
+	if err := json.Unmarshal(respBody, &tokens); err != nil {
+		return nil, fmt.Errorf("huggingface: unexpected embedding response shape: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("huggingface: no embedding returned for text")
+	}
+	return meanPool(tokens), nil
+}
+
+// meanPool averages per-token vectors into a single vector, for
+// feature-extraction models that return one embedding per input token
+// rather than one for the whole input.
+func meanPool(vectors [][]float32) []float32 {
+	pooled := make([]float32, len(vectors[0]))
+	for _, v := range vectors {
+		for i, x := range v {
+			pooled[i] += x
+		}
+	}
+	for i := range pooled {
+		pooled[i] /= float32(len(vectors))
+	}
+	return pooled
+}
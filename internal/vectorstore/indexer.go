@@ -6,8 +6,12 @@ import (
 	"Orkflow/internal/memory"
 )
 
-// IndexSession indexes all messages from a session into the vector store
-func IndexSession(store *ChromemStore, session *memory.Session) error {
+// IndexSession indexes all messages from a session into the vector store,
+// tagging each document with embedderID (see EmbedderIdentity) so a search
+// across documents indexed by different embedding backends can at least be
+// told apart after the fact, even where CheckEmbedderLock wasn't called
+// first.
+func IndexSession(store VectorStore, session *memory.Session, embedderID string) error {
 	for i, msg := range session.Messages {
 		// Create a unique document ID
 		docID := fmt.Sprintf("%s_%d", session.ID, i)
@@ -19,6 +23,7 @@ func IndexSession(store *ChromemStore, session *memory.Session) error {
 			"agent_id":   msg.AgentID,
 			"role":       msg.Role,
 			"timestamp":  msg.Timestamp.Format("2006-01-02 15:04:05"),
+			"embedder":   embedderID,
 		}
 
 		// Add document to vector store
@@ -0,0 +1,121 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pgvector/pgvector-go"
+)
+
+func init() {
+	Register("pgvector", pgvectorDriver{})
+}
+
+// pgvectorDriver opens a PGVectorStore against a Postgres database with the
+// pgvector extension enabled. Its DSN is a standard Postgres connection
+// string, e.g. "user:pass@host:5432/orka?sslmode=disable".
+type pgvectorDriver struct{}
+
+func (pgvectorDriver) Open(dsn string, opts Options) (VectorStore, error) {
+	ef, err := embeddingFuncFor(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, "postgres://"+dsn)
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: connect to pgvector: %w", err)
+	}
+
+	store := &PGVectorStore{pool: pool, ctx: ctx, embed: ef, table: "orka_documents"}
+	if err := store.ensureSchema(); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// PGVectorStore implements VectorStore against a Postgres database with the
+// pgvector extension, using cosine distance for similarity search.
+type PGVectorStore struct {
+	pool  *pgxpool.Pool
+	ctx   context.Context
+	embed EmbeddingFunc
+	table string
+}
+
+func (s *PGVectorStore) ensureSchema() error {
+	if _, err := s.pool.Exec(s.ctx, `CREATE EXTENSION IF NOT EXISTS vector`); err != nil {
+		return fmt.Errorf("vectorstore: enable pgvector extension: %w", err)
+	}
+
+	_, err := s.pool.Exec(s.ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			metadata JSONB NOT NULL DEFAULT '{}',
+			embedding VECTOR
+		)`, s.table))
+	if err != nil {
+		return fmt.Errorf("vectorstore: create table: %w", err)
+	}
+	return nil
+}
+
+// AddDocument adds a document to the vector store
+func (s *PGVectorStore) AddDocument(id string, content string, metadata map[string]string) error {
+	vec, err := s.embed(s.ctx, content)
+	if err != nil {
+		return fmt.Errorf("vectorstore: embed document %s: %w", id, err)
+	}
+
+	_, err = s.pool.Exec(s.ctx, fmt.Sprintf(`
+		INSERT INTO %s (id, content, metadata, embedding)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET content = $2, metadata = $3, embedding = $4
+	`, s.table), id, content, metadata, pgvector.NewVector(vec))
+	return err
+}
+
+// Search finds similar documents by cosine distance
+func (s *PGVectorStore) Search(query string, limit int) ([]SearchResult, error) {
+	vec, err := s.embed(s.ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: embed query: %w", err)
+	}
+
+	rows, err := s.pool.Query(s.ctx, fmt.Sprintf(`
+		SELECT id, content, metadata, 1 - (embedding <=> $1) AS similarity
+		FROM %s
+		ORDER BY embedding <=> $1
+		LIMIT $2
+	`, s.table), pgvector.NewVector(vec), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []SearchResult
+	for rows.Next() {
+		var r SearchResult
+		if err := rows.Scan(&r.ID, &r.Content, &r.Metadata, &r.Score); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// DeleteDocument removes a document from the store
+func (s *PGVectorStore) DeleteDocument(id string) error {
+	_, err := s.pool.Exec(s.ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, s.table), id)
+	return err
+}
+
+// Close releases the connection pool
+func (s *PGVectorStore) Close() error {
+	s.pool.Close()
+	return nil
+}
@@ -0,0 +1,188 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	qdrant "github.com/qdrant/go-client/qdrant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func init() {
+	Register("qdrant", qdrantDriver{})
+}
+
+// qdrantDriver opens a QdrantStore against a Qdrant instance reached over
+// gRPC. Its DSN is "host:port/collection", e.g. "localhost:6334/orka".
+type qdrantDriver struct{}
+
+func (qdrantDriver) Open(dsn string, opts Options) (VectorStore, error) {
+	ef, err := embeddingFuncFor(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, collection, ok := strings.Cut(dsn, "/")
+	if !ok || collection == "" {
+		return nil, fmt.Errorf("vectorstore: qdrant dsn must be \"host:port/collection\", got %q", dsn)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: connect to qdrant at %s: %w", addr, err)
+	}
+
+	store := &QdrantStore{
+		conn:        conn,
+		points:      qdrant.NewPointsClient(conn),
+		collections: qdrant.NewCollectionsClient(conn),
+		collection:  collection,
+		embed:       ef,
+		ctx:         context.Background(),
+		dims:        opts.EmbeddingDims,
+	}
+	if err := store.ensureCollection(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// QdrantStore implements VectorStore against a Qdrant collection. Document
+// IDs are arbitrary strings, but Qdrant point IDs must be a u64 or a UUID,
+// so each ID is mapped to a deterministic UUID (derived from the ID itself)
+// and the original ID is kept in the point's payload.
+type QdrantStore struct {
+	conn        *grpc.ClientConn
+	points      qdrant.PointsClient
+	collections qdrant.CollectionsClient
+	collection  string
+	embed       EmbeddingFunc
+	ctx         context.Context
+	dims        int // vector size to create the collection with; probed from embed if 0
+}
+
+func (s *QdrantStore) pointID(id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(id)).String()
+}
+
+// ensureCollection creates s.collection if it doesn't already exist, sizing
+// its vectors to s.dims (probed from the configured embedder if not set
+// explicitly) and comparing them with cosine distance, the metric every
+// embedding provider this package supports is tuned for.
+func (s *QdrantStore) ensureCollection() error {
+	size := s.dims
+	if size == 0 {
+		probe, err := s.embed(s.ctx, "vectorstore dimension probe")
+		if err != nil {
+			return fmt.Errorf("vectorstore: probe embedding dimension for qdrant collection %s: %w", s.collection, err)
+		}
+		size = len(probe)
+	}
+
+	_, err := s.collections.Create(s.ctx, &qdrant.CreateCollection{
+		CollectionName: s.collection,
+		VectorsConfig: &qdrant.VectorsConfig{
+			Config: &qdrant.VectorsConfig_Params{
+				Params: &qdrant.VectorParams{
+					Size:     uint64(size),
+					Distance: qdrant.Distance_Cosine,
+				},
+			},
+		},
+	})
+	if err != nil && !strings.Contains(err.Error(), "already exists") {
+		return fmt.Errorf("vectorstore: create qdrant collection %s: %w", s.collection, err)
+	}
+	return nil
+}
+
+// AddDocument adds a document to the vector store
+func (s *QdrantStore) AddDocument(id string, content string, metadata map[string]string) error {
+	vec, err := s.embed(s.ctx, content)
+	if err != nil {
+		return fmt.Errorf("vectorstore: embed document %s: %w", id, err)
+	}
+
+	payload := map[string]*qdrant.Value{
+		"id":      {Kind: &qdrant.Value_StringValue{StringValue: id}},
+		"content": {Kind: &qdrant.Value_StringValue{StringValue: content}},
+	}
+	for k, v := range metadata {
+		payload[k] = &qdrant.Value{Kind: &qdrant.Value_StringValue{StringValue: v}}
+	}
+
+	_, err = s.points.Upsert(s.ctx, &qdrant.UpsertPoints{
+		CollectionName: s.collection,
+		Points: []*qdrant.PointStruct{{
+			Id:      &qdrant.PointId{PointIdOptions: &qdrant.PointId_Uuid{Uuid: s.pointID(id)}},
+			Vectors: &qdrant.Vectors{VectorsOptions: &qdrant.Vectors_Vector{Vector: &qdrant.Vector{Data: vec}}},
+			Payload: payload,
+		}},
+	})
+	return err
+}
+
+// Search finds similar documents
+func (s *QdrantStore) Search(query string, limit int) ([]SearchResult, error) {
+	vec, err := s.embed(s.ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("vectorstore: embed query: %w", err)
+	}
+
+	resp, err := s.points.Search(s.ctx, &qdrant.SearchPoints{
+		CollectionName: s.collection,
+		Vector:         vec,
+		Limit:          uint64(limit),
+		WithPayload:    &qdrant.WithPayloadSelector{SelectorOptions: &qdrant.WithPayloadSelector_Enable{Enable: true}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, p := range resp.GetResult() {
+		metadata := make(map[string]string)
+		var docID, content string
+		for k, v := range p.GetPayload() {
+			switch k {
+			case "id":
+				docID = v.GetStringValue()
+			case "content":
+				content = v.GetStringValue()
+			default:
+				metadata[k] = v.GetStringValue()
+			}
+		}
+		results = append(results, SearchResult{
+			ID:       docID,
+			Content:  content,
+			Score:    p.GetScore(),
+			Metadata: metadata,
+		})
+	}
+	return results, nil
+}
+
+// DeleteDocument removes a document from the store
+func (s *QdrantStore) DeleteDocument(id string) error {
+	_, err := s.points.Delete(s.ctx, &qdrant.DeletePoints{
+		CollectionName: s.collection,
+		Points: &qdrant.PointsSelector{
+			PointsSelectorOneOf: &qdrant.PointsSelector_Points{
+				Points: &qdrant.PointsIdsList{
+					Ids: []*qdrant.PointId{{PointIdOptions: &qdrant.PointId_Uuid{Uuid: s.pointID(id)}}},
+				},
+			},
+		},
+	})
+	return err
+}
+
+// Close releases the gRPC connection
+func (s *QdrantStore) Close() error {
+	return s.conn.Close()
+}
@@ -7,8 +7,6 @@ import (
 	"time"
 
 	"Orkflow/internal/memory"
-
-	"github.com/philippgille/chromem-go"
 )
 
 // mockEmbeddingFunc is a mock implementation of chromem.EmbeddingFunc for testing
@@ -31,27 +29,11 @@ func TestChromemStore(t *testing.T) {
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// We need to bypass the hardcoded path in NewChromemStore...
-	// Since NewChromemStoreWith... functions mostly call newChromemStore which uses a hardcoded path relative to home.
-	// However, we can use the exported ChromemStore struct and initialize it manually for testing
-	// OR we can make the path configurable.
-	// Looking at vectorstore.go, newChromemStore has logic we might want to test, but it hardcodes the path.
-	// For now, let's copy the initialization logic here but use our temp dir.
-
-	db, err := chromem.NewPersistentDB(tmpDir, false)
-	if err != nil {
-		t.Fatalf("Failed to create chromem db: %v", err)
-	}
-
-	collection, err := db.GetOrCreateCollection("test_collection", nil, mockEmbeddingFunc)
+	// newChromemStore takes the DB path directly, so tests can point it at a
+	// temp directory instead of the real ~/.orka/vectordb.
+	store, err := newChromemStore(tmpDir, mockEmbeddingFunc)
 	if err != nil {
-		t.Fatalf("Failed to create collection: %v", err)
-	}
-
-	store := &ChromemStore{
-		db:         db,
-		collection: collection,
-		ctx:        context.Background(),
+		t.Fatalf("Failed to create chromem store: %v", err)
 	}
 
 	t.Run("Add and Search Document", func(t *testing.T) {
@@ -99,7 +81,7 @@ func TestChromemStore(t *testing.T) {
 			},
 		}
 
-		err := IndexSession(store, session)
+		err := IndexSession(store, session, "ollama/nomic-embed-text")
 		if err != nil {
 			t.Errorf("IndexSession failed: %v", err)
 		}
@@ -141,4 +123,72 @@ func TestChromemStore(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("Keyword and Hybrid Search", func(t *testing.T) {
+		if err := store.AddDocument("kw_doc_1", "error code ORKA-4021 during checkpoint resume", nil); err != nil {
+			t.Fatalf("AddDocument failed: %v", err)
+		}
+
+		results, err := store.SearchWithOptions("ORKA-4021", SearchOptions{Limit: 1, Mode: Keyword})
+		if err != nil {
+			t.Fatalf("SearchWithOptions(Keyword) failed: %v", err)
+		}
+		if len(results) == 0 || results[0].ID != "kw_doc_1" {
+			t.Errorf("expected kw_doc_1 as top keyword match, got %+v", results)
+		}
+
+		hybrid, err := store.SearchWithOptions("ORKA-4021", SearchOptions{Limit: 3, Mode: Hybrid})
+		if err != nil {
+			t.Fatalf("SearchWithOptions(Hybrid) failed: %v", err)
+		}
+		found := false
+		for _, r := range hybrid {
+			if r.ID == "kw_doc_1" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected kw_doc_1 in hybrid results, got %+v", hybrid)
+		}
+	})
+}
+
+func TestBM25Index(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "orka_test_bm25")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	idx, err := newBM25Index(tmpDir)
+	if err != nil {
+		t.Fatalf("newBM25Index failed: %v", err)
+	}
+	defer idx.Close()
+
+	if err := idx.Add("doc1", "the quick brown fox jumps over the lazy dog"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := idx.Add("doc2", "lorem ipsum dolor sit amet"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	results, err := idx.Search("quick fox", 5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].DocID != "doc1" {
+		t.Errorf("expected only doc1 to match \"quick fox\", got %+v", results)
+	}
+
+	if err := idx.Delete("doc1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	results, err = idx.Search("quick fox", 5)
+	if err != nil {
+		t.Fatalf("Search after delete failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no matches after deleting doc1, got %+v", results)
+	}
 }
@@ -7,9 +7,73 @@ type MCPServerConfig struct {
 	Env     []string `yaml:"env,omitempty"`
 }
 
+// EmbeddingConfig selects the embedding backend Smart Context and
+// `orka sessions search` vectorize session content with. Provider is one of
+// "ollama" (default), "openai", "mistral", "gemini", "cohere",
+// "huggingface", or "local-onnx" (recognized, but it always returns an
+// error); Model defaults to a sensible per-provider choice when empty and is
+// ignored for "mistral". APIKeyEnv names the environment variable to read the
+// provider's API key from, matching how Model.APIKey is resolved for
+// agents.
+type EmbeddingConfig struct {
+	Provider  string `yaml:"provider,omitempty"`
+	Model     string `yaml:"model,omitempty"`
+	Dims      int    `yaml:"dims,omitempty"`
+	APIKeyEnv string `yaml:"api_key_env,omitempty"`
+}
+
 type WorkflowConfig struct {
 	Agents     []Agent                    `yaml:"agents"`
 	Workflow   *WorkflowSpec              `yaml:"workflow,omitempty"`
 	Models     map[string]Model           `yaml:"models,omitempty"`
 	MCPServers map[string]MCPServerConfig `yaml:"mcp_servers,omitempty"`
+	Embeddings *EmbeddingConfig           `yaml:"embeddings,omitempty"`
+}
+
+// Step is a single agent invocation within a "sequential" or "dag"
+// Workflow. DependsOn, Timeout, Retries, and OnError are only meaningful
+// for "dag": DependsOn gates the step behind other steps' IDs finishing
+// first, Timeout (a time.ParseDuration string) bounds the step's whole run
+// - every attempt and any fallback together, not each attempt on its own -
+// Retries is how many attempts it gets, and OnError is either "continue"
+// (treat a final failure as an empty output and proceed) or
+// "fallback:<agent-id>" (run that agent instead).
+type Step struct {
+	Agent     string   `yaml:"agent"`
+	DependsOn []string `yaml:"depends_on,omitempty"`
+	Timeout   string   `yaml:"timeout,omitempty"`
+	Retries   int      `yaml:"retries,omitempty"`
+	OnError   string   `yaml:"on_error,omitempty"`
+}
+
+// ThenStep names the agent a "parallel" Workflow runs after every Branch
+// finishes. Its prompt is sent as written: {{ steps.<id>.output }}
+// placeholders are substituted only in "dag" steps.
+type ThenStep struct {
+	Agent string `yaml:"agent"`
+}
+
+// WorkflowSpec declares how Execute runs the configured agents. Type
+// selects the strategy: "sequential" runs Steps in order, "parallel" runs
+// Branches concurrently and then optionally Then, and "dag" runs Steps as
+// a DependsOn-gated graph, at most MaxParallel at a time (0 means
+// unlimited). A nil WorkflowConfig.Workflow instead runs whichever agent
+// IsSupervisor reports true for (or the first agent, if none do).
+type WorkflowSpec struct {
+	Type        string    `yaml:"type"`
+	Steps       []Step    `yaml:"steps,omitempty"`
+	Branches    []string  `yaml:"branches,omitempty"`
+	Then        *ThenStep `yaml:"then,omitempty"`
+	MaxParallel int       `yaml:"max_parallel,omitempty"`
+}
+
+// Model configures a named LLM backend that agents reference by name in
+// Agent.Model. Fallbacks lists other Models entries to retry against, in
+// order, when this one's provider errors or trips its circuit breaker.
+type Model struct {
+	Provider  string   `yaml:"provider"`
+	Model     string   `yaml:"model,omitempty"`
+	APIKey    string   `yaml:"api_key,omitempty"`
+	Endpoint  string   `yaml:"endpoint,omitempty"`
+	Fallbacks []string `yaml:"fallbacks,omitempty"`
 }